@@ -0,0 +1,14 @@
+// Package champions bundles a snapshot of the champions data into the binary, so that it works from any directory
+package champions
+
+import "embed"
+
+// Data Snapshot of the champions data directory (i.e. champions/lol), one sub-directory per patch
+//
+//go:embed all:lol
+var Data embed.FS
+
+// Overrides Snapshot of the champion overrides directory (i.e. champions/overrides), applied on top of Data
+//
+//go:embed all:overrides
+var Overrides embed.FS
@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
 
+	"github.com/J4NN0/league-of-legends-fight-tactics/champions"
 	"github.com/J4NN0/league-of-legends-fight-tactics/internal/command"
 	"github.com/J4NN0/league-of-legends-fight-tactics/internal/config"
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger"
@@ -24,23 +27,127 @@ func main() {
 		log.Fatalf("config reading failed: %v", err)
 		return
 	}
+	for _, name := range appConfig.DeprecatedVariables() {
+		log.Warningf("%s is deprecated and ignored, as Data Dragon needs neither an API key nor a region", name)
+	}
 
-	riotClient := riot.NewClient(log, &http.Client{}, appConfig.RiotAPIKey, appConfig.LoLRegion)
-	lolTactics := lol.NewTactics(log)
+	solver := lol.NewSolver(log)
+	cache := newCache(appConfig.CacheDir)
 
-	ctrl := command.New(log, riotClient, lolTactics)
+	// Riot client and champions data store depend on the global flags, so they are set once these are parsed
+	ctrl := command.New(log, nil, nil, solver)
+	ctrl.SetCache(cache)
 
 	rootCmd := &cobra.Command{
 		Use:   "loltactics",
 		Short: "league of legends fight tactics tool",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			dataDir, err := cmd.Flags().GetString("data-dir")
+			if err != nil {
+				return err
+			}
+			overridesDir, err := cmd.Flags().GetString("overrides-dir")
+			if err != nil {
+				return err
+			}
+			patch, err := cmd.Flags().GetString("patch")
+			if err != nil {
+				return err
+			}
+			locale, err := cmd.Flags().GetString("locale")
+			if err != nil {
+				return err
+			}
+
+			ctrl.SetChampionStore(newChampionStore(dataDir, overridesDir))
+			hc := &http.Client{Timeout: appConfig.HTTPTimeout}
+			riotOpts := riot.Options{
+				Patch:       patch,
+				Locale:      locale,
+				Cache:       cache,
+				CacheTTL:    appConfig.CacheTTL,
+				Retries:     appConfig.HTTPRetries,
+				RetryDelay:  appConfig.HTTPRetryDelay,
+				RateLimit:   appConfig.HTTPRateLimit,
+				Concurrency: appConfig.DownloadConcurrency,
+			}
+			ctrl.SetRiotClient(riot.NewClient(log, hc, riotOpts))
+			ctrl.SetCDragonClient(riot.NewCDragonClient(log, hc, appConfig.CDragonSource, riotOpts))
+			ctrl.SetPatch(patch)
+			ctrl.SetLocale(locale)
+			return nil
+		},
 	}
+	rootCmd.PersistentFlags().String("data-dir", appConfig.DataDir, "champions data directory (default to LOL_DATA_DIR, then champions/lol, then the data embedded into the binary)")
+	rootCmd.PersistentFlags().String("overrides-dir", appConfig.OverridesDir, "directory of the hand-written corrections applied on top of the champions data (default to LOL_OVERRIDES_DIR, then champions/overrides)")
+	rootCmd.PersistentFlags().String("patch", appConfig.LoLPatch, "patch champions data are downloaded from (e.g. 14.20.1) or read from (e.g. 14.20), default to LOL_PATCH, then the latest one")
+	rootCmd.PersistentFlags().String("locale", appConfig.LoLLocale, "locale champion and spell names are downloaded and shown in (e.g. it_IT), default to LOL_LOCALE, then en_US")
 	rootCmd.AddCommand(ctrl.FightCommand())
+	rootCmd.AddCommand(ctrl.TeamFightCommand())
 	rootCmd.AddCommand(ctrl.TacticsCommand())
 	rootCmd.AddCommand(ctrl.DownloadCommand())
 	rootCmd.AddCommand(ctrl.DownloadAllCommand())
+	rootCmd.AddCommand(ctrl.ImportDragontailCommand())
+	rootCmd.AddCommand(ctrl.PatchesCommand())
+	rootCmd.AddCommand(ctrl.DiffPatchCommand())
+	rootCmd.AddCommand(ctrl.CacheCommand())
+	rootCmd.AddCommand(ctrl.ValidateCommand())
+	rootCmd.AddCommand(ctrl.MigrateCommand())
+	rootCmd.AddCommand(ctrl.ConvertCommand())
+	rootCmd.AddCommand(ctrl.ListCommand())
+	rootCmd.AddCommand(ctrl.ShowCommand())
 
-	if err := rootCmd.Execute(); err != nil {
+	err = rootCmd.Execute()
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
+
+// newChampionStore Champions data directory if given, otherwise champions/lol if run from the repository root.
+// As last resort, the read-only champions data snapshot embedded into the binary.
+// Overrides are applied on top, from the given directory or otherwise champions/overrides if run from the repository root,
+// falling back on the overrides embedded along with the snapshot when it is used.
+func newChampionStore(dataDir, overridesDir string) lol.PatchStore {
+	var store lol.PatchStore
+	embedded := false
+	if dataDir != "" {
+		store = lol.NewDirPatchStore(dataDir)
+	} else if isDir(lol.DefaultChampionsDir) {
+		store = lol.NewDirPatchStore(lol.DefaultChampionsDir)
+	} else {
+		store = lol.NewFSPatchStore(champions.Data, "lol")
+		embedded = true
+	}
+
+	if overridesDir == "" && isDir(lol.DefaultOverridesDir) {
+		overridesDir = lol.DefaultOverridesDir
+	}
+	if overridesDir != "" {
+		store = lol.NewOverridePatchStore(store, os.DirFS(overridesDir))
+	} else if embedded {
+		overrides, err := fs.Sub(champions.Overrides, "overrides")
+		if err == nil {
+			store = lol.NewOverridePatchStore(store, overrides)
+		}
+	}
+	return store
+}
+
+func isDir(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// newCache Data Dragon responses cache inside the given directory, otherwise inside the user cache directory (e.g. ~/.cache/lol-tactics).
+// Responses are not cached at all if there is no user cache directory.
+func newCache(cacheDir string) riot.Cache {
+	if cacheDir != "" {
+		return riot.NewDiskCache(cacheDir)
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+	return riot.NewDiskCache(filepath.Join(userCacheDir, appName))
+}
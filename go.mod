@@ -3,12 +3,12 @@ module github.com/J4NN0/league-of-legends-fight-tactics
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/KnutZuidema/golio v0.0.0-20220228083517-e9a630a5acc7
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/sirupsen/logrus v1.9.0
 	github.com/spf13/cobra v1.8.0
 	github.com/stretchr/testify v1.8.0
-	golang.org/x/text v0.3.7
 	gopkg.in/yaml.v2 v2.4.0
 )
 
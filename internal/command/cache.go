@@ -0,0 +1,78 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot"
+	"github.com/spf13/cobra"
+)
+
+var errCacheDisabled = errors.New("data dragon responses cache is disabled")
+
+func (c *Controller) CacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "manage the cache of data dragon responses",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "stats",
+		Short: "show how many responses are cached, by patch",
+		Args:  cobra.ExactArgs(0),
+		Run:   c.cacheStats,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear [patch...]",
+		Short: "remove the cached responses of the given patches, or all of them if none is given",
+		Run:   c.cacheClear,
+	})
+	return cmd
+}
+
+func (c *Controller) cacheStats(cmd *cobra.Command, args []string) {
+	if c.cache == nil {
+		cmd.PrintErr(errCacheDisabled)
+		os.Exit(-1)
+	}
+
+	stats, err := c.cache.Stats()
+	if err != nil {
+		cmd.PrintErrf("reading cache: %v", err)
+		os.Exit(-1)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), getCacheStatsToString(stats))
+}
+
+func (c *Controller) cacheClear(cmd *cobra.Command, args []string) {
+	if c.cache == nil {
+		cmd.PrintErr(errCacheDisabled)
+		os.Exit(-1)
+	}
+
+	err := c.cache.Clear(args...)
+	if err != nil {
+		cmd.PrintErrf("clearing cache: %v", err)
+		os.Exit(-1)
+	}
+
+	c.log.Printf("Cache cleared")
+}
+
+func getCacheStatsToString(stats riot.CacheStats) string {
+	statsToString := fmt.Sprintf("Cache directory: %s\n", stats.Dir)
+	statsToString += fmt.Sprintf("Cached responses: %d (%.2f MB)\n", stats.Entries, float64(stats.Bytes)/(1024*1024))
+
+	patches := make([]string, 0, len(stats.Patches))
+	for patch := range stats.Patches {
+		patches = append(patches, patch)
+	}
+	sort.Strings(patches)
+	for _, patch := range patches {
+		statsToString += fmt.Sprintf("  %s: %d\n", patch, stats.Patches[patch])
+	}
+
+	return statsToString
+}
@@ -0,0 +1,16 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCacheStatsToString(t *testing.T) {
+	stats := riot.CacheStats{Dir: "/tmp/cache", Entries: 3, Bytes: 3 * 1024 * 1024, Patches: map[string]int{"13.1.1": 2, "12.3.1": 1}}
+
+	statsToString := getCacheStatsToString(stats)
+
+	assert.Equal(t, "Cache directory: /tmp/cache\nCached responses: 3 (3.00 MB)\n  12.3.1: 1\n  13.1.1: 2\n", statsToString)
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,40 +11,174 @@ import (
 	"github.com/KnutZuidema/golio/datadragon"
 )
 
-const (
-	baseChampionPath = "champions/lol"
-	fileExtension    = "yml"
-)
-
 type Controller struct {
-	log        logger.Logger
-	riotClient riot.Client
-	lolTactics lol.Tactics
+	log           logger.Logger
+	riotClient    riot.Client
+	cdragonClient riot.CDragonClient // source of the spell numbers when downloading from CommunityDragon
+	cache         riot.Cache         // cache of the riot client responses, nil if disabled
+	championStore lol.PatchStore
+	patch         string // patch champions data are read from, the latest stored one if empty
+	locale        string // locale champions data are downloaded and shown in, lol.DefaultLocale if empty
+	solver        lol.Solver
+}
+
+func New(log logger.Logger, riotClient riot.Client, championStore lol.PatchStore, solver lol.Solver) *Controller {
+	return &Controller{log: log, riotClient: riotClient, championStore: championStore, solver: solver}
+}
+
+// SetRiotClient Replace the client champions data are downloaded with
+func (c *Controller) SetRiotClient(riotClient riot.Client) {
+	c.riotClient = riotClient
+}
+
+// SetCDragonClient Replace the client spell numbers are downloaded with from CommunityDragon
+func (c *Controller) SetCDragonClient(cdragonClient riot.CDragonClient) {
+	c.cdragonClient = cdragonClient
+}
+
+// SetCache Set the cache of the riot client responses (nil if disabled), managed by the cache command
+func (c *Controller) SetCache(cache riot.Cache) {
+	c.cache = cache
+}
+
+// SetChampionStore Replace the store champions data are read from and written to
+func (c *Controller) SetChampionStore(championStore lol.PatchStore) {
+	c.championStore = championStore
+}
+
+// SetPatch Set the patch champions data are read from (e.g. 14.20), the latest stored one if empty
+func (c *Controller) SetPatch(patch string) {
+	c.patch = patch
 }
 
-func New(log logger.Logger, riotClient riot.Client, lolTactics lol.Tactics) *Controller {
-	return &Controller{log: log, riotClient: riotClient, lolTactics: lolTactics}
+// SetLocale Set the locale champions data are downloaded and shown in (e.g. it_IT), lol.DefaultLocale if empty
+func (c *Controller) SetLocale(locale string) {
+	c.locale = locale
 }
 
-func setFilePath(champion1, champion2 lol.Champion) string {
-	return fmt.Sprintf("fights/%s_vs_%s.loltactics", champion1.Name, champion2.Name)
+// championRepository Champions data of the selected patch
+func (c *Controller) championRepository() (lol.ChampionRepository, error) {
+	patch, err := c.championStore.ResolvePatch(c.patch)
+	if err != nil {
+		return nil, err
+	}
+	c.log.Printf("Using champions data of patch %s", patch)
+	return c.championStore.Patch(patch), nil
+}
+
+// resolveChampionNames Ids of the champions the names refer to (e.g. MonkeyKing for wukong, Kaisa for Kai'Sa), failing with an
+// *lol.UnknownChampionError suggesting the closest champions on the first name matching none
+func resolveChampionNames(championRepo lol.ChampionRepository, names ...string) ([]string, error) {
+	index, err := lol.NewChampionIndexFromRepository(championRepo)
+	if err != nil {
+		return nil, fmt.Errorf("listing champions: %v", err)
+	}
+
+	ids := make([]string, len(names))
+	for i, name := range names {
+		ids[i], err = index.Resolve(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// getFightFileName Name (without extension) of the file the fight results are written to (e.g. KaiSa_vs_TwistedFate)
+func getFightFileName(champion1, champion2 lol.Champion) string {
+	return fmt.Sprintf("%s_vs_%s", safeFileName(champion1.Name), safeFileName(champion2.Name))
+}
+
+// getTeamFightFileName Name (without extension) of the file the team fight results are written to (e.g. Ahri-Jhin_vs_Lucian)
+func getTeamFightFileName(team1, team2 []lol.Champion) string {
+	var names [2][]string
+	for i, team := range [2][]lol.Champion{team1, team2} {
+		for _, champion := range team {
+			names[i] = append(names[i], safeFileName(champion.Name))
+		}
+	}
+	return fmt.Sprintf("%s_vs_%s", strings.Join(names[0], "-"), strings.Join(names[1], "-"))
+}
+
+// getSpellToString Spell id, followed by its (localized) name if any
+func getSpellToString(spell lol.Spell) string {
+	if spell.Name == "" || spell.Name == spell.ID {
+		return spell.ID
+	}
+	return fmt.Sprintf("%s (%s)", spell.ID, spell.Name)
 }
 
 func getRoundSpellsToString(spells []lol.Spell, hp, benchmark float64) string {
 	var spellsToString string
 	for _, s := range spells {
-		spellsToString += fmt.Sprintf("%s: %.2f (hp: %.2f -> %.2f)\n", s.ID, s.Damage, hp, hp-s.Damage[s.MaxRank-1])
+		spellsToString += fmt.Sprintf("%s: %.2f (hp: %.2f -> %.2f)\n", getSpellToString(s), s.Damage[s.MaxRank-1], hp, hp-s.Damage[s.MaxRank-1])
 		hp = hp - s.Damage[s.MaxRank-1]
 	}
 	spellsToString += fmt.Sprintf("\nEnemy defeated in %.2fs\n", benchmark)
 	return spellsToString
 }
 
+func getDuelToString(duel lol.DuelSol) string {
+	duelToString := "\nDuel (both champions fighting at the same time)\n"
+	for _, side := range duel.Sides {
+		duelToString += fmt.Sprintf("\n%s:\n", side.Champion)
+		for _, a := range side.Actions {
+			duelToString += fmt.Sprintf("[%.2fs] %s: %.2f (enemy hp: %.2f, distance: %.2f)\n", a.Time, getSpellToString(a.Spell), a.Spell.Damage[a.Spell.MaxRank-1], a.EnemyHp, a.Distance)
+		}
+		duelToString += fmt.Sprintf("Damage dealt: %.2f, CC applied: %.2fs, time moving: %.2fs, hp left: %.2f\n", side.DamageDealt, side.CCApplied, side.TimeMoving, side.HealthPoints)
+	}
+	if duel.Winner != "" {
+		duelToString += fmt.Sprintf("\n%s won the duel in %.2fs\n", duel.Winner, duel.Duration)
+	} else {
+		duelToString += fmt.Sprintf("\nDuel ended in a draw after %.2fs\n", duel.Duration)
+	}
+	return duelToString
+}
+
+func getTeamFightToString(teamFight lol.TeamFightSol) string {
+	var teamFightToString string
+	for i, team := range teamFight.Teams {
+		teamFightToString += fmt.Sprintf("Team %d:\n", i+1)
+		for _, champion := range team {
+			status := "survived"
+			if champion.DeathTime >= 0 {
+				status = fmt.Sprintf("died at %.2fs", champion.DeathTime)
+			}
+			teamFightToString += fmt.Sprintf("%s: %s (damage dealt: %.2f, CC applied: %.2fs, hp left: %.2f)\n", champion.Name, status, champion.DamageDealt, champion.CCApplied, champion.HealthPoints)
+		}
+		teamFightToString += "\n"
+	}
+	if teamFight.Winner != 0 {
+		teamFightToString += fmt.Sprintf("Team %d won the team fight in %.2fs\n", teamFight.Winner, teamFight.Duration)
+	} else {
+		teamFightToString += fmt.Sprintf("Team fight ended in a draw after %.2fs\n", teamFight.Duration)
+	}
+	return teamFightToString
+}
+
 func (c *Controller) storeChampionToYMLFile(ddChampion datadragon.ChampionDataExtended) error {
-	lolChampion := mapChampionResponseToLolChampionStruct(ddChampion)
-	filePath := getYMLPath(lolChampion.ID)
+	return c.storeChampion(mapChampionResponseToLolChampionStruct(ddChampion))
+}
+
+func (c *Controller) storeChampion(lolChampion lol.Champion) error {
+	if lolChampion.Patch == "" {
+		return fmt.Errorf("unknown patch of %s champion data", lolChampion.Name)
+	}
+	lolChampion.Locale = c.locale
+	if lolChampion.Locale == "" {
+		lolChampion.Locale = lol.DefaultLocale
+	}
 
-	err := c.lolTactics.WriteChampion(lolChampion, filePath)
+	// Keep the names already stored in other locales (as downloaded, overrides must not end up in the downloaded data)
+	championRepo := lol.WithoutOverrides(c.championStore).Patch(lolChampion.Patch)
+	storedChampion, err := championRepo.ReadChampion(lolChampion.ID)
+	if err == nil {
+		lolChampion = lol.MergeLocales(storedChampion, lolChampion)
+	} else if !errors.Is(err, lol.ErrChampionNotFound) {
+		c.log.Warningf("Could not load stored %s champion data, overwriting it: %v", lolChampion.Name, err)
+	}
+
+	err = championRepo.WriteChampion(lolChampion)
 	if err != nil {
 		return err
 	}
@@ -57,6 +192,7 @@ func mapChampionResponseToLolChampionStruct(ddChampion datadragon.ChampionDataEx
 		Name:  ddChampion.ChampionData.Name,
 		Title: ddChampion.Title,
 		Tags:  strings.Join(ddChampion.Tags, ", "),
+		Patch: ddChampion.Version,
 		Passive: lol.Passive{
 			Name:        ddChampion.Passive.Name,
 			Description: ddChampion.Passive.Description,
@@ -65,6 +201,8 @@ func mapChampionResponseToLolChampionStruct(ddChampion datadragon.ChampionDataEx
 			HealthPoints: ddChampion.Stats.HealthPoints,
 			AttackDamage: ddChampion.Stats.AttackDamage,
 			AttackSpeed:  ddChampion.Stats.AttackSpeedOffset,
+			AttackRange:  ddChampion.Stats.AttackRange,
+			MoveSpeed:    ddChampion.Stats.MovementSpeed,
 		},
 		Spells: []lol.Spell{
 			{
@@ -80,24 +218,60 @@ func mapChampionResponseToLolChampionStruct(ddChampion datadragon.ChampionDataEx
 
 	// Add remaining spells
 	for _, spell := range ddChampion.Spells {
-		var spellDamages []float64
-		if len(spell.Effect) >= 1 {
-			spellDamages = spell.Effect[1] // effect and effectBurn arrays have a null value in the 0 index (aka they are arrays 1-based)
+		damage := riot.ParseSpellDamage(spell)
+		if damage.Values == nil {
+			damage.Values = make([]float64, spell.MaxRank) // no damage found, better no damage than a wrong one
 		}
 
 		lolChampion.Spells = append(lolChampion.Spells, lol.Spell{
-			ID:       spell.ID,
-			Name:     spell.Name,
-			Damage:   spellDamages,
-			MaxRank:  spell.MaxRank,
-			Cooldown: spell.Cooldown,
-			Cast:     0.0, // it cannot be retrieved from DataDragon APIs
+			ID:         spell.ID,
+			Name:       spell.Name,
+			Damage:     damage.Values,
+			Ratios:     damage.Ratios,
+			DamageType: damage.Type,
+			Confidence: damage.Confidence,
+			MaxRank:    spell.MaxRank,
+			Cooldown:   spell.Cooldown,
+			Cast:       0.0, // it cannot be retrieved from DataDragon APIs
+			Range:      spell.Range,
 		})
 	}
 
 	return lolChampion
 }
 
-func getYMLPath(championName string) string {
-	return fmt.Sprintf("%s/%s.%s", baseChampionPath, strings.ReplaceAll(strings.ToLower(championName), " ", ""), fileExtension)
+// applyCDragonSpells Replace the Data Dragon spell numbers with the CommunityDragon ones, for every spell both sources know of
+func applyCDragonSpells(lolChampion lol.Champion, cdSpells map[string]riot.CDragonSpell) lol.Champion {
+	spells := make([]lol.Spell, len(lolChampion.Spells))
+	for i, spell := range lolChampion.Spells {
+		if cdSpell, ok := cdSpells[spell.ID]; ok {
+			if damage := spellRanks(cdSpell.Damage, nil, spell.MaxRank); damage != nil {
+				spell.Damage = damage
+				spell.Confidence = 1 // read from the game files, no guess involved
+			}
+			spell.Cooldown = spellRanks(cdSpell.Cooldown, spell.Cooldown, spell.MaxRank)
+			spell.Range = spellRanks(cdSpell.Range, spell.Range, spell.MaxRank)
+			spell.Cast = cdSpell.Cast
+			for stat, ratio := range cdSpell.Ratios {
+				if spell.Ratios == nil {
+					spell.Ratios = map[string][]float64{}
+				}
+				spell.Ratios[stat] = spellRanks(ratio, nil, spell.MaxRank)
+			}
+		}
+		spells[i] = spell
+	}
+	lolChampion.Spells = spells
+	return lolChampion
+}
+
+// spellRanks Values of the ranks a spell can actually reach, or the fallback ones if some are missing
+func spellRanks(values, fallback []float64, maxRank int) []float64 {
+	if len(values) < maxRank || len(values) == 0 {
+		return fallback
+	}
+	if maxRank > 0 {
+		return values[:maxRank]
+	}
+	return values
 }
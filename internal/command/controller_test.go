@@ -2,23 +2,53 @@ package command
 
 import (
 	"errors"
-	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
 	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot"
 	"github.com/KnutZuidema/golio/datadragon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func getMockLoLChampion() lol.Champion {
-	return lol.Champion{
+// mockChampionOption Change to the mock champion, for the fields a test depends on
+type mockChampionOption func(*lol.Champion)
+
+// withName Mock champion of the given name, its id being the name without spaces
+func withName(name string) mockChampionOption {
+	return func(champion *lol.Champion) {
+		champion.ID = strings.ReplaceAll(name, " ", "")
+		champion.Name = name
+	}
+}
+
+func withTags(tags string) mockChampionOption {
+	return func(champion *lol.Champion) { champion.Tags = tags }
+}
+
+func withPatch(patch string) mockChampionOption {
+	return func(champion *lol.Champion) { champion.Patch = patch }
+}
+
+func withStats(stats lol.Stats) mockChampionOption {
+	return func(champion *lol.Champion) { champion.Stats = stats }
+}
+
+func withSpells(spells ...lol.Spell) mockChampionOption {
+	return func(champion *lol.Champion) { champion.Spells = spells }
+}
+
+// getMockLoLChampion Champion mapped from getMockDDChampion, changed by the given options
+func getMockLoLChampion(opts ...mockChampionOption) lol.Champion {
+	champion := lol.Champion{
 		ID:    "mockID",
 		Name:  "mockName",
 		Title: "mockTitle",
 		Tags:  "Some, tags, here",
+		Patch: "13.1.1",
 		Passive: lol.Passive{
 			Name:        "passiveName",
 			Description: "passiveDescription",
@@ -27,6 +57,8 @@ func getMockLoLChampion() lol.Champion {
 			HealthPoints: 50,
 			AttackDamage: 10,
 			AttackSpeed:  2,
+			AttackRange:  125,
+			MoveSpeed:    340,
 		},
 		Spells: []lol.Spell{
 			{
@@ -38,28 +70,37 @@ func getMockLoLChampion() lol.Champion {
 				Cast:     0,
 			},
 			{
-				ID:       "q",
-				Name:     "QName",
-				MaxRank:  5,
-				Cooldown: []float64{10, 8, 6, 4, 2},
-				Damage:   []float64{8, 10, 12, 14, 16},
-				Cast:     0,
+				ID:         "q",
+				Name:       "QName",
+				MaxRank:    5,
+				Cooldown:   []float64{10, 8, 6, 4, 2},
+				Damage:     []float64{8, 10, 12, 14, 16},
+				Confidence: 0.2, // no tooltip, first effect taken
+				Cast:       0,
+				Range:      []float64{600, 600, 600, 600, 600},
 			},
 		},
 	}
+	for _, opt := range opts {
+		opt(&champion)
+	}
+	return champion
 }
 
 func getMockDDChampion() datadragon.ChampionDataExtended {
 	return datadragon.ChampionDataExtended{
 		ChampionData: datadragon.ChampionData{
-			ID:    "mockID",
-			Name:  "mockName",
-			Title: "mockTitle",
-			Tags:  []string{"Some", "tags", "here"},
+			Version: "13.1.1",
+			ID:      "mockID",
+			Name:    "mockName",
+			Title:   "mockTitle",
+			Tags:    []string{"Some", "tags", "here"},
 			Stats: datadragon.ChampionDataStats{
 				HealthPoints:      50,
 				AttackDamage:      10,
 				AttackSpeedOffset: 2,
+				AttackRange:       125,
+				MovementSpeed:     340,
 			},
 		},
 		Passive: datadragon.PassiveData{
@@ -73,6 +114,7 @@ func getMockDDChampion() datadragon.ChampionDataExtended {
 				MaxRank:  5,
 				Cooldown: []float64{10, 8, 6, 4, 2},
 				Effect:   [][]float64{nil, {8, 10, 12, 14, 16}},
+				Range:    []float64{600, 600, 600, 600, 600},
 			},
 		},
 	}
@@ -96,25 +138,90 @@ func TestGetRoundSpellsToString(t *testing.T) {
 	}
 
 	spellsToString := getRoundSpellsToString(spells, hp, benchmark)
-	expectedString := fmt.Sprintf("%s: %.2f (hp: %.2f -> %.2f)\n", spells[0].ID, spells[0].Damage, hp, hp-spells[0].Damage[spells[0].MaxRank-1])
-	expectedString += fmt.Sprintf("%s: %.2f (hp: %.2f -> %.2f)\n", spells[1].ID, spells[1].Damage, hp-spells[0].Damage[spells[0].MaxRank-1], hp-spells[0].Damage[spells[0].MaxRank-1]-spells[1].Damage[spells[0].MaxRank-1])
-	expectedString += fmt.Sprintf("\nEnemy defeated in %.2fs\n", benchmark)
+	expectedString := "q: 10.00 (hp: 15.00 -> 5.00)\nw: 20.00 (hp: 5.00 -> -15.00)\n\nEnemy defeated in 3.00s\n"
 
 	assert.Equal(t, expectedString, spellsToString)
 }
 
-func TestSetFilePath(t *testing.T) {
-	filename := setFilePath(lol.Champion{Name: "Name1"}, lol.Champion{Name: "Name2"})
+func TestGetDuelToString(t *testing.T) {
+	aa := lol.Spell{ID: "aa", Damage: []float64{10}, MaxRank: 1}
+	duel := lol.DuelSol{
+		Winner:   "Name1",
+		Duration: 1,
+		Sides: [2]lol.DuelSide{
+			{Champion: "Name1", Actions: []lol.DuelAction{{Time: 0, Spell: aa, EnemyHp: 10}, {Time: 1, Spell: aa, EnemyHp: 0}}, DamageDealt: 20, CCApplied: 1.5, HealthPoints: 10},
+			{Champion: "Name2", Actions: []lol.DuelAction{{Time: 0, Spell: aa, EnemyHp: 10}}, DamageDealt: 10, HealthPoints: 0},
+		},
+	}
+
+	t.Run("winner", func(t *testing.T) {
+		duelToString := getDuelToString(duel)
+
+		expectedString := "\nDuel (both champions fighting at the same time)\n"
+		expectedString += "\nName1:\n[0.00s] aa: 10.00 (enemy hp: 10.00, distance: 0.00)\n[1.00s] aa: 10.00 (enemy hp: 0.00, distance: 0.00)\nDamage dealt: 20.00, CC applied: 1.50s, time moving: 0.00s, hp left: 10.00\n"
+		expectedString += "\nName2:\n[0.00s] aa: 10.00 (enemy hp: 10.00, distance: 0.00)\nDamage dealt: 10.00, CC applied: 0.00s, time moving: 0.00s, hp left: 0.00\n"
+		expectedString += "\nName1 won the duel in 1.00s\n"
+
+		assert.Equal(t, expectedString, duelToString)
+	})
+
+	t.Run("draw", func(t *testing.T) {
+		draw := lol.DuelSol{Duration: 180, Sides: [2]lol.DuelSide{{Champion: "Name1"}, {Champion: "Name2"}}}
+
+		duelToString := getDuelToString(draw)
 
-	assert.Equal(t, "fights/Name1_vs_Name2.loltactics", filename)
+		assert.Contains(t, duelToString, "\nDuel ended in a draw after 180.00s\n")
+	})
+}
+
+func TestGetFightFileName(t *testing.T) {
+	t.Run("plain names", func(t *testing.T) {
+		assert.Equal(t, "Name1_vs_Name2", getFightFileName(lol.Champion{Name: "Name1"}, lol.Champion{Name: "Name2"}))
+	})
+
+	t.Run("names with spaces and punctuation", func(t *testing.T) {
+		assert.Equal(t, "KaiSa_vs_NunuWillump", getFightFileName(lol.Champion{Name: "Kai'Sa"}, lol.Champion{Name: "Nunu & Willump"}))
+	})
+
+	t.Run("names with path separators", func(t *testing.T) {
+		assert.Equal(t, "Name1_vs_Name2", getFightFileName(lol.Champion{Name: "../Name1"}, lol.Champion{Name: "Name/2"}))
+	})
+}
+
+func TestGetTeamFightFileName(t *testing.T) {
+	filename := getTeamFightFileName([]lol.Champion{{Name: "Name1"}, {Name: "Dr. Mundo"}}, []lol.Champion{{Name: "Name3"}})
+
+	assert.Equal(t, "Name1-DrMundo_vs_Name3", filename)
+}
+
+func TestGetTeamFightToString(t *testing.T) {
+	teamFight := lol.TeamFightSol{
+		Winner:   1,
+		Duration: 4,
+		Teams: [2][]lol.TeamFightChampion{
+			{{Name: "Name1", DeathTime: -1, DamageDealt: 50, HealthPoints: 60}, {Name: "Name2", DeathTime: 3, DamageDealt: 50}},
+			{{Name: "Name3", DeathTime: 4, DamageDealt: 140, CCApplied: 1}},
+		},
+	}
+
+	teamFightToString := getTeamFightToString(teamFight)
+
+	expectedString := "Team 1:\nName1: survived (damage dealt: 50.00, CC applied: 0.00s, hp left: 60.00)\nName2: died at 3.00s (damage dealt: 50.00, CC applied: 0.00s, hp left: 0.00)\n\n"
+	expectedString += "Team 2:\nName3: died at 4.00s (damage dealt: 140.00, CC applied: 1.00s, hp left: 0.00)\n\n"
+	expectedString += "Team 1 won the team fight in 4.00s\n"
+
+	assert.Equal(t, expectedString, teamFightToString)
 }
 
 func TestStoreChampionToYMLFile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("WriteChampion", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("string")).Return(nil)
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.AnythingOfType("lol.Champion")).Return(nil)
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
 
-		ctrl := New(&loggertest.Logger{}, nil, mockLol)
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
 
 		err := ctrl.storeChampionToYMLFile(getMockDDChampion())
 
@@ -122,10 +229,13 @@ func TestStoreChampionToYMLFile(t *testing.T) {
 	})
 
 	t.Run("fail Write", func(t *testing.T) {
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("WriteChampion", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("string")).Return(errors.New("some error"))
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.AnythingOfType("lol.Champion")).Return(errors.New("some error"))
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
 
-		ctrl := New(&loggertest.Logger{}, nil, mockLol)
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
 
 		err := ctrl.storeChampionToYMLFile(getMockDDChampion())
 
@@ -133,30 +243,89 @@ func TestStoreChampionToYMLFile(t *testing.T) {
 	})
 }
 
+func TestStoreChampionToYMLFileLocale(t *testing.T) {
+	championRepo := lol.NewMemoryRepository()
+	mockStore := &lolMocks.PatchStore{}
+	mockStore.On("Patch", "13.1.1").Return(championRepo)
+
+	ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+	err := ctrl.storeChampionToYMLFile(getMockDDChampion())
+	assert.Nil(t, err)
+
+	italianChampion := getMockDDChampion()
+	italianChampion.Title = "mockTitolo"
+	ctrl.SetLocale("it_IT")
+	err = ctrl.storeChampionToYMLFile(italianChampion)
+	assert.Nil(t, err)
+
+	lolChampion, err := championRepo.ReadChampion("mockID")
+	assert.Nil(t, err)
+	assert.Equal(t, lol.DefaultLocale, lolChampion.Locale)
+	assert.Equal(t, "mockTitle", lolChampion.Title)
+	assert.Equal(t, "mockTitolo", lolChampion.Localize("it_IT").Title)
+}
+
+func TestGetSpellToString(t *testing.T) {
+	t.Run("with name", func(t *testing.T) {
+		assert.Equal(t, "AhriQ (Orb of Deception)", getSpellToString(lol.Spell{ID: "AhriQ", Name: "Orb of Deception"}))
+	})
+
+	t.Run("without name", func(t *testing.T) {
+		assert.Equal(t, "aa", getSpellToString(lol.Spell{ID: "aa"}))
+	})
+}
+
 func TestMapChampionResponseToLolChampionStruct(t *testing.T) {
 	lolChampion := mapChampionResponseToLolChampionStruct(getMockDDChampion())
 	assert.Equal(t, getMockLoLChampion(), lolChampion)
 }
 
-func TestGetYMLPath(t *testing.T) {
-	t.Run("lowercase name", func(t *testing.T) {
-		path := getYMLPath("name")
-		expectedPath := baseChampionPath + "/name." + fileExtension
+func TestApplyCDragonSpells(t *testing.T) {
+	lolChampion := mapChampionResponseToLolChampionStruct(getMockDDChampion())
 
-		assert.Equal(t, expectedPath, path)
+	champion := applyCDragonSpells(lolChampion, map[string]riot.CDragonSpell{
+		"q": {
+			ID:       "q",
+			Damage:   []float64{40, 60, 80, 100, 120, 140},
+			Ratios:   map[string][]float64{"TotalADRatio": {0.5, 0.6, 0.7, 0.8, 0.9, 1}},
+			Cooldown: []float64{9, 8, 7},
+			Cast:     0.25,
+			Range:    []float64{650, 650, 650, 650, 650, 650, 650},
+		},
+		"w": {ID: "w", Damage: []float64{1, 2, 3}},
 	})
 
-	t.Run("uppercase name", func(t *testing.T) {
-		path := getYMLPath("NAME")
-		expectedPath := baseChampionPath + "/name." + fileExtension
+	assert.Equal(t, lolChampion.Spells[0], champion.Spells[0]) // auto attack untouched
+	assert.Equal(t, lol.Spell{
+		ID:         "q",
+		Name:       "QName",
+		MaxRank:    5,
+		Damage:     []float64{40, 60, 80, 100, 120},
+		Ratios:     map[string][]float64{"TotalADRatio": {0.5, 0.6, 0.7, 0.8, 0.9}},
+		Confidence: 1,
+		Cooldown:   []float64{10, 8, 6, 4, 2}, // not enough ranks, Data Dragon ones kept
+		Cast:       0.25,
+		Range:      []float64{650, 650, 650, 650, 650},
+	}, champion.Spells[1])
+	assert.Len(t, champion.Spells, 2)
+	assert.Equal(t, []float64{8, 10, 12, 14, 16}, lolChampion.Spells[1].Damage) // original champion untouched
+}
+
+func TestResolveChampionNames(t *testing.T) {
+	championRepo := lol.NewMemoryRepository(lol.Champion{ID: "MonkeyKing", Name: "Wukong"}, lol.Champion{ID: "Jhin", Name: "Jhin"})
 
-		assert.Equal(t, expectedPath, path)
+	t.Run("success", func(t *testing.T) {
+		ids, err := resolveChampionNames(championRepo, "wukong", "JHIN")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"MonkeyKing", "Jhin"}, ids)
 	})
 
-	t.Run("multi case plus spaces", func(t *testing.T) {
-		path := getYMLPath("SomE nAme")
-		expectedPath := baseChampionPath + "/somename." + fileExtension
+	t.Run("unknown", func(t *testing.T) {
+		_, err := resolveChampionNames(championRepo, "wukong", "jihn")
 
-		assert.Equal(t, expectedPath, path)
+		assert.True(t, errors.Is(err, lol.ErrChampionNotFound))
+		assert.Equal(t, "champion not found: jihn (did you mean Jhin?)", err.Error())
 	})
 }
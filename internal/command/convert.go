@@ -0,0 +1,69 @@
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+func (c *Controller) ConvertCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "convert format [champion...]",
+		Short: fmt.Sprintf("rewrite the champion files of the selected patch (all of them if none is given) in the given format (%s, %s or %s)", lol.FormatYAML, lol.FormatJSON, lol.FormatTOML),
+		Args:  cobra.MinimumNArgs(1),
+		Run:   c.convert,
+	}
+}
+
+func (c *Controller) convert(cmd *cobra.Command, args []string) {
+	err := c.convertChampions(args[0], args[1:])
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+}
+
+// convertChampions Rewrite the given champion files of the selected patch in the given format, all of them if none is given
+func (c *Controller) convertChampions(format string, names []string) error {
+	if format == "yaml" {
+		format = lol.FormatYAML
+	}
+	if format != lol.FormatYAML && format != lol.FormatJSON && format != lol.FormatTOML {
+		return fmt.Errorf("unknown format %q, expected %s, %s or %s", format, lol.FormatYAML, lol.FormatJSON, lol.FormatTOML)
+	}
+
+	// Overrides stay as they are, only the downloaded data is converted
+	championStore := lol.WithoutOverrides(c.championStore)
+	patch, err := championStore.ResolvePatch(c.patch)
+	if err != nil {
+		return err
+	}
+	converter, ok := championStore.Patch(patch).(lol.ChampionFileConverter)
+	if !ok {
+		return fmt.Errorf("champion files of patch %s cannot be converted (read-only data?)", patch)
+	}
+
+	if len(names) == 0 {
+		names, err = championStore.Patch(patch).ListChampions()
+		if err != nil {
+			return fmt.Errorf("listing champions of patch %s: %v", patch, err)
+		}
+	} else {
+		names, err = resolveChampionNames(championStore.Patch(patch), names...)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		err = converter.ConvertChampion(name, format)
+		if err != nil {
+			return fmt.Errorf("converting %s: %w", name, err)
+		}
+	}
+
+	c.log.Printf("%d champion files of patch %s converted to %s", len(names), patch, format)
+	return nil
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertChampions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		store := lol.NewDirPatchStore(dir)
+		for _, name := range []string{"Ahri", "Jhin"} {
+			err := store.Patch("14.20.1").WriteChampion(getMockLoLChampion(withName(name)))
+			assert.Nil(t, err)
+		}
+
+		ctrl := New(&loggertest.Logger{}, nil, store, nil)
+
+		err := ctrl.convertChampions(lol.FormatJSON, []string{"jhin"})
+
+		assert.Nil(t, err)
+		entries, err := os.ReadDir(filepath.Join(dir, "14.20.1"))
+		assert.Nil(t, err)
+		assert.Equal(t, "ahri.yml", entries[0].Name())
+		assert.Equal(t, "jhin.json", entries[1].Name())
+
+		err = ctrl.convertChampions(lol.FormatTOML, nil)
+
+		assert.Nil(t, err)
+		entries, err = os.ReadDir(filepath.Join(dir, "14.20.1"))
+		assert.Nil(t, err)
+		assert.Equal(t, "ahri.toml", entries[0].Name())
+		assert.Equal(t, "jhin.toml", entries[1].Name())
+	})
+
+	t.Run("resolved names", func(t *testing.T) {
+		dir := t.TempDir()
+		store := lol.NewDirPatchStore(dir)
+		for _, name := range []string{"Twisted Fate", "Jhin"} {
+			err := store.Patch("14.20.1").WriteChampion(getMockLoLChampion(withName(name)))
+			assert.Nil(t, err)
+		}
+
+		ctrl := New(&loggertest.Logger{}, nil, store, nil)
+
+		err := ctrl.convertChampions(lol.FormatJSON, []string{"tf"})
+
+		assert.Nil(t, err)
+		_, err = os.Stat(filepath.Join(dir, "14.20.1", "twistedfate.json"))
+		assert.Nil(t, err)
+
+		err = ctrl.convertChampions(lol.FormatJSON, []string{"jihn"})
+
+		var unknownErr *lol.UnknownChampionError
+		assert.True(t, errors.As(err, &unknownErr))
+		assert.Equal(t, []string{"Jhin"}, unknownErr.Suggestions)
+		_, err = os.Stat(filepath.Join(dir, "14.20.1", "jhin.yml"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		ctrl := New(&loggertest.Logger{}, nil, nil, nil)
+
+		err := ctrl.convertChampions("xml", nil)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "").Return("14.20.1", nil)
+		mockStore.On("Patch", "14.20.1").Return(lol.NewMemoryRepository())
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		err := ctrl.convertChampions(lol.FormatJSON, nil)
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,257 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffFormatText = "text"
+	diffFormatJSON = "json"
+)
+
+// Status of a champion between two patches
+const (
+	championChanged = "changed"
+	championAdded   = "added"
+	championRemoved = "removed"
+)
+
+type patchDiff struct {
+	OldPatch  string            `json:"old_patch"`
+	NewPatch  string            `json:"new_patch"`
+	Champions []championDiff    `json:"champions"`
+	Duels     []duelDiff        `json:"duels"`
+	Invalid   []invalidChampion `json:"invalid,omitempty"`
+}
+
+type championDiff struct {
+	Champion string          `json:"champion"`
+	Status   string          `json:"status"`
+	Fields   []lol.FieldDiff `json:"fields,omitempty"`
+}
+
+// duelDiff Duel (see lol.Solver.Duel, both champions attacking each other) between two champions available in both patches, where at
+// least one of them changed. Unlike the fight one, its benchmark is the time until the first champion dies, as the optimal round of
+// spells of every changed matchup would take far too long to compute
+type duelDiff struct {
+	Champion1   string  `json:"champion1"`
+	Champion2   string  `json:"champion2"`
+	OldWinner   string  `json:"old_winner"` // empty in case of draw
+	NewWinner   string  `json:"new_winner"`
+	OldDuration float64 `json:"old_duration"` // time (in seconds) at which the duel ended
+	NewDuration float64 `json:"new_duration"`
+}
+
+// invalidChampion Champion skipped from the diff, as it could not be loaded from one of the patches
+type invalidChampion struct {
+	Patch    string `json:"patch"`
+	Champion string `json:"champion"`
+	Error    string `json:"error"`
+}
+
+func (c *Controller) DiffPatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff-patch",
+		Aliases: []string{"dp"},
+		Short:   "show the champions changed between two stored patches and how their duels changed (e.g. diff-patch 14.19 14.20)",
+		Args:    cobra.ExactArgs(2),
+		Run:     c.diffPatch,
+	}
+	cmd.Flags().String("format", diffFormatText, fmt.Sprintf("output format (%s or %s)", diffFormatText, diffFormatJSON))
+	cmd.Flags().Float64("distance", 0, "initial distance between the two champions in each duel (0 means both start in range)")
+	return cmd
+}
+
+func (c *Controller) diffPatch(cmd *cobra.Command, args []string) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	if format != diffFormatText && format != diffFormatJSON {
+		cmd.PrintErrf("unknown format %q", format)
+		os.Exit(-1)
+	}
+	distance, err := cmd.Flags().GetFloat64("distance")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	diff, err := c.diffPatches(args[0], args[1], lol.DuelOptions{Distance: distance})
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	if format == diffFormatJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			cmd.PrintErr(err)
+			os.Exit(-1)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), getPatchDiffToString(diff))
+	}
+}
+
+func (c *Controller) diffPatches(oldPatch, newPatch string, duelOpts lol.DuelOptions) (patchDiff, error) {
+	var champions [2]map[string]lol.Champion
+	diff := patchDiff{}
+
+	for i, patch := range []string{oldPatch, newPatch} {
+		resolved, err := c.championStore.ResolvePatch(patch)
+		if err != nil {
+			return patchDiff{}, err
+		}
+		if i == 0 {
+			diff.OldPatch = resolved
+		} else {
+			diff.NewPatch = resolved
+		}
+
+		var invalid []invalidChampion
+		champions[i], invalid, err = readAllChampions(c.championStore.Patch(resolved))
+		if err != nil {
+			return patchDiff{}, fmt.Errorf("loading champions of patch %s: %v", resolved, err)
+		}
+		for _, champion := range invalid {
+			c.log.Warningf("Skipping %s champion of patch %s: %s", champion.Champion, resolved, champion.Error)
+			champion.Patch = resolved
+			diff.Invalid = append(diff.Invalid, champion)
+		}
+	}
+	// A champion invalid in one patch only would otherwise be reported as added or removed
+	for _, champion := range diff.Invalid {
+		delete(champions[0], champion.Champion)
+		delete(champions[1], champion.Champion)
+	}
+
+	var common []string
+	changed := map[string]bool{}
+	for _, name := range sortedChampionNames(champions[0], champions[1]) {
+		oldChampion, inOld := champions[0][name]
+		newChampion, inNew := champions[1][name]
+		switch {
+		case !inNew:
+			diff.Champions = append(diff.Champions, championDiff{Champion: oldChampion.Name, Status: championRemoved})
+		case !inOld:
+			diff.Champions = append(diff.Champions, championDiff{Champion: newChampion.Name, Status: championAdded})
+		default:
+			common = append(common, name)
+			if fields := lol.DiffChampions(oldChampion, newChampion); len(fields) > 0 {
+				changed[name] = true
+				diff.Champions = append(diff.Champions, championDiff{Champion: newChampion.Name, Status: championChanged, Fields: fields})
+			}
+		}
+	}
+
+	c.log.Printf("Simulating duels of the %d champions changed between patch %s and %s ...\n", len(changed), diff.OldPatch, diff.NewPatch)
+	for i, c1 := range common {
+		for _, c2 := range common[i+1:] {
+			if !changed[c1] && !changed[c2] {
+				continue
+			}
+
+			oldDuel := c.solver.Duel(champions[0][c1], champions[0][c2], duelOpts)
+			newDuel := c.solver.Duel(champions[1][c1], champions[1][c2], duelOpts)
+			if oldDuel.Winner == newDuel.Winner && oldDuel.Duration == newDuel.Duration {
+				continue
+			}
+
+			diff.Duels = append(diff.Duels, duelDiff{
+				Champion1:   champions[1][c1].Name,
+				Champion2:   champions[1][c2].Name,
+				OldWinner:   oldDuel.Winner,
+				NewWinner:   newDuel.Winner,
+				OldDuration: oldDuel.Duration,
+				NewDuration: newDuel.Duration,
+			})
+		}
+	}
+
+	return diff, nil
+}
+
+// readAllChampions Every valid champion of the repository, by name, along with the ones that could not be loaded
+func readAllChampions(championRepo lol.ChampionRepository) (map[string]lol.Champion, []invalidChampion, error) {
+	names, err := championRepo.ListChampions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	champions := make(map[string]lol.Champion, len(names))
+	var invalid []invalidChampion
+	for _, name := range names {
+		champion, err := championRepo.ReadChampion(name)
+		if err != nil {
+			invalid = append(invalid, invalidChampion{Champion: name, Error: err.Error()})
+			continue
+		}
+		champions[name] = champion
+	}
+	return champions, invalid, nil
+}
+
+func sortedChampionNames(champions ...map[string]lol.Champion) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, m := range champions {
+		for name := range m {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getPatchDiffToString(diff patchDiff) string {
+	diffToString := fmt.Sprintf("Patch %s -> %s\n", diff.OldPatch, diff.NewPatch)
+
+	diffToString += fmt.Sprintf("\nChampions (%d):\n", len(diff.Champions))
+	for _, champion := range diff.Champions {
+		diffToString += fmt.Sprintf("%s (%s)\n", champion.Champion, champion.Status)
+		for _, f := range champion.Fields {
+			diffToString += fmt.Sprintf("  %s: %v -> %v\n", f.Field, f.Old, f.New)
+		}
+	}
+
+	diffToString += fmt.Sprintf("\nDuels, both champions attacking each other until one dies (%d):\n", len(diff.Duels))
+	for _, d := range diff.Duels {
+		diffToString += fmt.Sprintf("%s vs %s: %s -> %s", d.Champion1, d.Champion2, getDuelOutcomeToString(d.OldWinner, d.OldDuration), getDuelOutcomeToString(d.NewWinner, d.NewDuration))
+		if d.OldWinner == d.NewWinner && d.NewWinner != "" {
+			if d.NewDuration < d.OldDuration {
+				diffToString += fmt.Sprintf(" (%.2fs faster)", d.OldDuration-d.NewDuration)
+			} else {
+				diffToString += fmt.Sprintf(" (%.2fs slower)", d.NewDuration-d.OldDuration)
+			}
+		}
+		diffToString += "\n"
+	}
+
+	if len(diff.Invalid) > 0 {
+		diffToString += fmt.Sprintf("\nInvalid champions, skipped (%d):\n", len(diff.Invalid))
+		for _, champion := range diff.Invalid {
+			diffToString += fmt.Sprintf("%s (patch %s): %s\n", champion.Champion, champion.Patch, champion.Error)
+		}
+	}
+
+	return diffToString
+}
+
+func getDuelOutcomeToString(winner string, duration float64) string {
+	if winner == "" {
+		return fmt.Sprintf("draw after %.2fs", duration)
+	}
+	return fmt.Sprintf("%s won in %.2fs", winner, duration)
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPatches(t *testing.T) {
+	stats := withStats(lol.Stats{HealthPoints: 100})
+	aa := withSpells(lol.Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}})
+	buffedAA := withSpells(lol.Spell{ID: "aa", MaxRank: 1, Damage: []float64{20}, Cooldown: []float64{1}})
+
+	t.Run("success", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "14.19").Once().Return("14.19.1", nil)
+		mockStore.On("ResolvePatch", "14.20").Once().Return("14.20.1", nil)
+		mockStore.On("Patch", "14.19.1").Once().Return(lol.NewMemoryRepository(
+			getMockLoLChampion(withName("Ahri"), stats, aa),
+			getMockLoLChampion(withName("Jhin"), stats, aa),
+			getMockLoLChampion(withName("Lucian"), stats, aa),
+			getMockLoLChampion(withName("Garen"), stats, aa),
+		))
+		mockStore.On("Patch", "14.20.1").Once().Return(lol.NewMemoryRepository(
+			getMockLoLChampion(withName("Ahri"), stats, buffedAA), // buffed
+			getMockLoLChampion(withName("Jhin"), stats, aa),
+			getMockLoLChampion(withName("Lucian"), stats, aa),
+			getMockLoLChampion(withName("Leona"), stats, aa),
+		))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, lol.NewSolver(&loggertest.Logger{}))
+
+		diff, err := ctrl.diffPatches("14.19", "14.20", lol.DuelOptions{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "14.19.1", diff.OldPatch)
+		assert.Equal(t, "14.20.1", diff.NewPatch)
+		assert.Equal(t, []championDiff{
+			{Champion: "Ahri", Status: championChanged, Fields: []lol.FieldDiff{{Field: "spells[aa].damage", Old: []float64{10}, New: []float64{20}}}},
+			{Champion: "Garen", Status: championRemoved},
+			{Champion: "Leona", Status: championAdded},
+		}, diff.Champions)
+		assert.Empty(t, diff.Invalid)
+		assert.Equal(t, []duelDiff{
+			{Champion1: "Ahri", Champion2: "Jhin", OldWinner: "", NewWinner: "Ahri", OldDuration: 9, NewDuration: 4},
+			{Champion1: "Ahri", Champion2: "Lucian", OldWinner: "", NewWinner: "Ahri", OldDuration: 9, NewDuration: 4},
+		}, diff.Duels)
+	})
+
+	t.Run("invalid champion skipped", func(t *testing.T) {
+		newRepo := &lolMocks.ChampionRepository{}
+		newRepo.On("ListChampions").Once().Return([]string{"ahri", "jhin"}, nil)
+		newRepo.On("ReadChampion", "ahri").Once().Return(getMockLoLChampion(withName("Ahri"), stats, buffedAA), nil)
+		newRepo.On("ReadChampion", "jhin").Once().Return(lol.Champion{}, errors.New("some error"))
+
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "14.19").Once().Return("14.19.1", nil)
+		mockStore.On("ResolvePatch", "14.20").Once().Return("14.20.1", nil)
+		mockStore.On("Patch", "14.19.1").Once().Return(lol.NewMemoryRepository(
+			getMockLoLChampion(withName("Ahri"), stats, aa),
+			getMockLoLChampion(withName("Jhin"), stats, aa),
+		))
+		mockStore.On("Patch", "14.20.1").Once().Return(newRepo)
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, lol.NewSolver(&loggertest.Logger{}))
+
+		diff, err := ctrl.diffPatches("14.19", "14.20", lol.DuelOptions{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []invalidChampion{{Patch: "14.20.1", Champion: "jhin", Error: "some error"}}, diff.Invalid)
+		assert.Equal(t, []championDiff{
+			{Champion: "Ahri", Status: championChanged, Fields: []lol.FieldDiff{{Field: "spells[aa].damage", Old: []float64{10}, New: []float64{20}}}},
+		}, diff.Champions) // not reported as removed
+		assert.Empty(t, diff.Duels)
+		newRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail ResolvePatch", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "14.19").Once().Return("", errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		_, err := ctrl.diffPatches("14.19", "14.20", lol.DuelOptions{})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetPatchDiffToString(t *testing.T) {
+	diff := patchDiff{
+		OldPatch: "14.19.1",
+		NewPatch: "14.20.1",
+		Champions: []championDiff{
+			{Champion: "Ahri", Status: championChanged, Fields: []lol.FieldDiff{{Field: "stats.health_points", Old: 590.0, New: 570.0}}},
+			{Champion: "Leona", Status: championAdded},
+		},
+		Duels: []duelDiff{
+			{Champion1: "Ahri", Champion2: "Jhin", OldWinner: "Ahri", NewWinner: "Ahri", OldDuration: 5, NewDuration: 4.5},
+			{Champion1: "Ahri", Champion2: "Lucian", OldWinner: "Ahri", NewWinner: "", OldDuration: 5, NewDuration: 6},
+		},
+		Invalid: []invalidChampion{{Patch: "14.20.1", Champion: "jhin", Error: "some error"}},
+	}
+
+	expectedString := "Patch 14.19.1 -> 14.20.1\n" +
+		"\nChampions (2):\n" +
+		"Ahri (changed)\n" +
+		"  stats.health_points: 590 -> 570\n" +
+		"Leona (added)\n" +
+		"\nDuels, both champions attacking each other until one dies (2):\n" +
+		"Ahri vs Jhin: Ahri won in 5.00s -> Ahri won in 4.50s (0.50s faster)\n" +
+		"Ahri vs Lucian: Ahri won in 5.00s -> draw after 6.00s\n" +
+		"\nInvalid champions, skipped (1):\n" +
+		"jhin (patch 14.20.1): some error\n"
+
+	assert.Equal(t, expectedString, getPatchDiffToString(diff))
+}
@@ -1,52 +1,104 @@
 package command
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot"
+	"github.com/KnutZuidema/golio/datadragon"
 	"github.com/spf13/cobra"
 )
 
+// Sources of the spell numbers: Data Dragon only, or CommunityDragon on top of Data Dragon
+const (
+	sourceDDragon = "ddragon"
+	sourceCDragon = "cdragon"
+)
+
+const sourceFlagUsage = "source of the spell numbers: ddragon, or cdragon for the far more accurate CommunityDragon ones (names and stats still come from Data Dragon)"
+
 func (c *Controller) DownloadCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "download",
 		Aliases: []string{"d"},
 		Short:   "download and update a specific league of legends champion (name must not to contain spaces)",
 		Args:    cobra.ExactArgs(1),
 		Run:     c.download,
 	}
+	cmd.Flags().String("source", sourceDDragon, sourceFlagUsage)
+	return cmd
 }
 
 func (c *Controller) DownloadAllCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "download_all",
 		Aliases: []string{"a", "-da"},
 		Short:   "download and update all league of legends champion",
 		Args:    cobra.ExactArgs(0),
 		Run:     c.downloadAll,
 	}
+	cmd.Flags().String("source", sourceDDragon, sourceFlagUsage)
+	return cmd
+}
+
+func getSourceFlag(cmd *cobra.Command) (string, error) {
+	source, err := cmd.Flags().GetString("source")
+	if err != nil {
+		return "", err
+	}
+	if source != sourceDDragon && source != sourceCDragon {
+		return "", fmt.Errorf("unknown source %q, expected %s or %s", source, sourceDDragon, sourceCDragon)
+	}
+	return source, nil
 }
 
 func (c *Controller) download(cmd *cobra.Command, args []string) {
-	championName := strings.ToLower(args[0])
+	source, err := getSourceFlag(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
 
-	err := c.fetchChampion(championName)
+	ctx, stop := interruptibleContext(cmd)
+	defer stop()
+
+	err = c.fetchChampion(ctx, args[0], source)
 	if err != nil {
 		cmd.PrintErr(err)
 		os.Exit(-1)
 	}
 }
 
-func (c *Controller) fetchChampion(championName string) error {
+// interruptibleContext Context of the command cancelled on Ctrl-C (or SIGTERM), so that in-flight downloads stop. The default handling of
+// the signals is restored as soon as one is caught, so that a second Ctrl-C kills the process whatever it is doing
+func interruptibleContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+func (c *Controller) fetchChampion(ctx context.Context, championName, source string) error {
 	c.log.Printf("Fetching %s ...", championName)
 
-	championData, err := c.riotClient.GetLoLChampion(championName)
+	championData, err := c.riotClient.GetLoLChampion(ctx, championName)
 	if err != nil {
 		return fmt.Errorf("fetching league of legends champions: %v", err)
 	}
 
-	err = c.storeChampionToYMLFile(championData)
+	lolChampion, err := c.mapDownloadedChampion(ctx, championData, source)
+	if err != nil {
+		return err
+	}
+
+	err = c.storeChampion(lolChampion)
 	if err != nil {
 		return fmt.Errorf("could not store %s champion data: %v", championName, err)
 	}
@@ -57,23 +109,39 @@ func (c *Controller) fetchChampion(championName string) error {
 }
 
 func (c *Controller) downloadAll(cmd *cobra.Command, args []string) {
-	err := c.fetchAllChampions()
+	source, err := getSourceFlag(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	ctx, stop := interruptibleContext(cmd)
+	defer stop()
+
+	err = c.fetchAllChampions(ctx, source)
 	if err != nil {
 		cmd.PrintErr(err)
 		os.Exit(-1)
 	}
 }
 
-func (c *Controller) fetchAllChampions() error {
+func (c *Controller) fetchAllChampions(ctx context.Context, source string) error {
 	c.log.Printf("Fetching all league of legends champions ...\n")
 
-	ddChampions, err := c.riotClient.GetAllLoLChampions()
-	if err != nil {
+	// On partial failure, the champions that could be fetched are still stored before reporting the others
+	ddChampions, err := c.riotClient.GetAllLoLChampions(ctx)
+	var downloadErr *riot.DownloadError
+	if err != nil && !errors.As(err, &downloadErr) {
 		return fmt.Errorf("fetching all league of legends champions: %v", err)
 	}
 
 	for _, champion := range ddChampions {
-		err = c.storeChampionToYMLFile(champion)
+		lolChampion, err := c.mapDownloadedChampion(ctx, champion, source)
+		if err != nil {
+			c.log.Warningf("Skipping %s champion: %v", champion.ChampionData.Name, err)
+			continue
+		}
+		err = c.storeChampion(lolChampion)
 		if err != nil {
 			c.log.Warningf("Could not store %s champion data: %v", champion.ChampionData.Name, err)
 		} else {
@@ -81,5 +149,23 @@ func (c *Controller) fetchAllChampions() error {
 		}
 	}
 
+	if downloadErr != nil {
+		return fmt.Errorf("fetching all league of legends champions: %w", downloadErr)
+	}
+
 	return nil
 }
+
+// mapDownloadedChampion Champion as downloaded from Data Dragon, with the CommunityDragon spell numbers if that is the source
+func (c *Controller) mapDownloadedChampion(ctx context.Context, ddChampion datadragon.ChampionDataExtended, source string) (lol.Champion, error) {
+	lolChampion := mapChampionResponseToLolChampionStruct(ddChampion)
+	if source != sourceCDragon {
+		return lolChampion, nil
+	}
+
+	cdSpells, err := c.cdragonClient.GetChampionSpells(ctx, ddChampion.Version, ddChampion.ID)
+	if err != nil {
+		return lol.Champion{}, fmt.Errorf("fetching spell numbers: %v", err)
+	}
+	return applyCDragonSpells(lolChampion, cdSpells), nil
+}
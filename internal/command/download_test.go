@@ -1,11 +1,14 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
 	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot"
 	riotMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot/mocks"
 	"github.com/KnutZuidema/golio/datadragon"
 	"github.com/stretchr/testify/assert"
@@ -15,37 +18,81 @@ import (
 func TestFetchChampion(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockRiot := &riotMocks.Client{}
-		mockRiot.On("GetLoLChampion", mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), nil)
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("WriteChampion", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("string")).Once().Return(nil)
+		mockRiot.On("GetLoLChampion", mock.Anything, mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), nil)
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.AnythingOfType("lol.Champion")).Once().Return(nil)
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
 
-		ctrl := New(&loggertest.Logger{}, mockRiot, mockLol)
+		ctrl := New(&loggertest.Logger{}, mockRiot, mockStore, nil)
 
-		err := ctrl.fetchChampion("mockName")
+		err := ctrl.fetchChampion(context.Background(), "mockName", sourceDDragon)
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("success cdragon", func(t *testing.T) {
+		mockRiot := &riotMocks.Client{}
+		mockRiot.On("GetLoLChampion", mock.Anything, mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), nil)
+		mockCDragon := &riotMocks.CDragonClient{}
+		mockCDragon.On("GetChampionSpells", mock.Anything, "13.1.1", "mockID").Once().Return(map[string]riot.CDragonSpell{
+			"q": {ID: "q", Damage: []float64{40, 60, 80, 100, 120, 140}, Cooldown: []float64{9, 8, 7, 6, 5}, Cast: 0.25},
+		}, nil)
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.MatchedBy(func(champion lol.Champion) bool {
+			return champion.Spells[1].ID == "q" && champion.Spells[1].Damage[4] == 120 && champion.Spells[1].Cast == 0.25
+		})).Once().Return(nil)
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
+
+		ctrl := New(&loggertest.Logger{}, mockRiot, mockStore, nil)
+		ctrl.SetCDragonClient(mockCDragon)
+
+		err := ctrl.fetchChampion(context.Background(), "mockName", sourceCDragon)
+
+		assert.Nil(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail GetChampionSpells", func(t *testing.T) {
+		mockRiot := &riotMocks.Client{}
+		mockRiot.On("GetLoLChampion", mock.Anything, mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), nil)
+		mockCDragon := &riotMocks.CDragonClient{}
+		mockCDragon.On("GetChampionSpells", mock.Anything, "13.1.1", "mockID").Once().Return(nil, errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, mockRiot, nil, nil)
+		ctrl.SetCDragonClient(mockCDragon)
+
+		err := ctrl.fetchChampion(context.Background(), "mockName", sourceCDragon)
+
+		assert.NotNil(t, err)
+	})
+
 	t.Run("fail GetLoLChampion", func(t *testing.T) {
 		mockRiot := &riotMocks.Client{}
-		mockRiot.On("GetLoLChampion", mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), errors.New("some error"))
+		mockRiot.On("GetLoLChampion", mock.Anything, mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), errors.New("some error"))
 
-		ctrl := New(&loggertest.Logger{}, mockRiot, nil)
+		ctrl := New(&loggertest.Logger{}, mockRiot, nil, nil)
 
-		err := ctrl.fetchChampion("mockName")
+		err := ctrl.fetchChampion(context.Background(), "mockName", sourceDDragon)
 
 		assert.NotNil(t, err)
 	})
 
 	t.Run("fail Write", func(t *testing.T) {
 		mockRiot := &riotMocks.Client{}
-		mockRiot.On("GetLoLChampion", mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), nil)
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("WriteChampion", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("string")).Once().Return(errors.New("some error"))
+		mockRiot.On("GetLoLChampion", mock.Anything, mock.AnythingOfType("string")).Once().Return(getMockDDChampion(), nil)
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.AnythingOfType("lol.Champion")).Once().Return(errors.New("some error"))
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
 
-		ctrl := New(&loggertest.Logger{}, mockRiot, mockLol)
+		ctrl := New(&loggertest.Logger{}, mockRiot, mockStore, nil)
 
-		err := ctrl.fetchChampion("mockName")
+		err := ctrl.fetchChampion(context.Background(), "mockName", sourceDDragon)
 
 		assert.NotNil(t, err)
 	})
@@ -54,24 +101,45 @@ func TestFetchChampion(t *testing.T) {
 func TestFetchAllChampions(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockRiot := &riotMocks.Client{}
-		mockRiot.On("GetAllLoLChampions").Once().Return([]datadragon.ChampionDataExtended{getMockDDChampion()}, nil)
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("WriteChampion", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("string")).Return(nil)
+		mockRiot.On("GetAllLoLChampions", mock.Anything).Once().Return([]datadragon.ChampionDataExtended{getMockDDChampion()}, nil)
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.AnythingOfType("lol.Champion")).Return(nil)
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
 
-		ctrl := New(&loggertest.Logger{}, mockRiot, mockLol)
+		ctrl := New(&loggertest.Logger{}, mockRiot, mockStore, nil)
 
-		err := ctrl.fetchAllChampions()
+		err := ctrl.fetchAllChampions(context.Background(), sourceDDragon)
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("partial failure", func(t *testing.T) {
+		downloadErr := &riot.DownloadError{Total: 2, Failed: []riot.ChampionError{{ChampionID: "Teemo", Err: errors.New("some error")}}}
+		mockRiot := &riotMocks.Client{}
+		mockRiot.On("GetAllLoLChampions", mock.Anything).Once().Return([]datadragon.ChampionDataExtended{getMockDDChampion()}, downloadErr)
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockID").Return(lol.Champion{}, lol.ErrChampionNotFound)
+		mockRepo.On("WriteChampion", mock.AnythingOfType("lol.Champion")).Once().Return(nil)
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Return(mockRepo)
+
+		ctrl := New(&loggertest.Logger{}, mockRiot, mockStore, nil)
+
+		err := ctrl.fetchAllChampions(context.Background(), sourceDDragon)
+
+		assert.True(t, errors.Is(err, downloadErr))
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("fail GetAllLoLChampions", func(t *testing.T) {
 		mockRiot := &riotMocks.Client{}
-		mockRiot.On("GetAllLoLChampions").Once().Return(nil, errors.New("some error"))
+		mockRiot.On("GetAllLoLChampions", mock.Anything).Once().Return(nil, errors.New("some error"))
 
-		ctrl := New(&loggertest.Logger{}, mockRiot, nil)
+		ctrl := New(&loggertest.Logger{}, mockRiot, nil, nil)
 
-		err := ctrl.fetchAllChampions()
+		err := ctrl.fetchAllChampions(context.Background(), sourceDDragon)
 
 		assert.NotNil(t, err)
 	})
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/riot"
+	"github.com/spf13/cobra"
+)
+
+func (c *Controller) ImportDragontailCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "import-dragontail",
+		Aliases: []string{"dt"},
+		Short:   "import all league of legends champions from a Data Dragon archive (dragontail-<version>.tgz or the directory it was extracted to), without network access",
+		Args:    cobra.ExactArgs(1),
+		Run:     c.importDragontail,
+	}
+}
+
+func (c *Controller) importDragontail(cmd *cobra.Command, args []string) {
+	err := c.importChampions(args[0])
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+}
+
+func (c *Controller) importChampions(dragontailPath string) error {
+	c.log.Printf("Importing league of legends champions from %s ...\n", dragontailPath)
+
+	ddChampions, err := riot.ReadDragontail(dragontailPath, c.locale)
+	if err != nil {
+		return fmt.Errorf("reading data dragon archive: %v", err)
+	}
+
+	for _, champion := range ddChampions {
+		err = c.storeChampionToYMLFile(champion)
+		if err != nil {
+			c.log.Warningf("Could not store %s champion data: %v", champion.ChampionData.Name, err)
+		} else {
+			c.log.Printf("%s successfully stored (patch %s)", champion.ChampionData.Name, champion.Version)
+		}
+	}
+
+	return nil
+}
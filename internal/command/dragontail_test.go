@@ -0,0 +1,43 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportChampions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		championDir := filepath.Join(dir, "13.1.1", "data", "en_US", "champion")
+		assert.Nil(t, os.MkdirAll(championDir, 0700))
+		assert.Nil(t, os.WriteFile(filepath.Join(championDir, "Jhin.json"), []byte(`{"data": {"Jhin": {"id": "Jhin", "name": "Jhin", "stats": {"hp": 655}}}}`), 0600))
+
+		championRepo := lol.NewMemoryRepository()
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("Patch", "13.1.1").Once().Return(championRepo)
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		err := ctrl.importChampions(dir)
+
+		assert.Nil(t, err)
+		champion, err := championRepo.ReadChampion("jhin")
+		assert.Nil(t, err)
+		assert.Equal(t, "13.1.1", champion.Patch)
+		assert.Equal(t, 655.0, champion.Stats.HealthPoints)
+	})
+
+	t.Run("fail ReadDragontail", func(t *testing.T) {
+		ctrl := New(&loggertest.Logger{}, nil, nil, nil)
+
+		err := ctrl.importChampions(t.TempDir())
+
+		assert.NotNil(t, err)
+	})
+}
@@ -3,52 +3,96 @@ package command
 import (
 	"fmt"
 	"os"
-	"strings"
 
-	"github.com/J4NN0/league-of-legends-fight-tactics/internal/file"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
 	"github.com/spf13/cobra"
 )
 
 func (c *Controller) FightCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "fight",
 		Aliases: []string{"f"},
 		Short:   "league of legends champions name",
 		Args:    cobra.ExactArgs(2),
 		Run:     c.fight,
 	}
+	cmd.Flags().Float64("distance", 0, "initial distance between the two champions in the duel (0 means both start in range)")
+	addResultFormatFlag(cmd)
+	addResultOutputFlag(cmd)
+	return cmd
 }
 
 func (c *Controller) fight(cmd *cobra.Command, args []string) {
-	championName1 := strings.ToLower(args[0])
-	championName2 := strings.ToLower(args[1])
+	distance, err := cmd.Flags().GetFloat64("distance")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	format, err := getResultFormatFlag(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	results, err := getResultWriter(cmd, format)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
 
-	err := c.championsFight(championName1, championName2)
+	championRepo, err := c.championRepository()
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	championNames, err := resolveChampionNames(championRepo, args[0], args[1])
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	err = c.championsFight(championRepo, championNames[0], championNames[1], lol.DuelOptions{Distance: distance}, results)
 	if err != nil {
 		cmd.PrintErr(err)
 		os.Exit(-1)
 	}
 }
 
-func (c *Controller) championsFight(championName1, championName2 string) error {
+func (c *Controller) championsFight(championRepo lol.ChampionRepository, championName1, championName2 string, duelOpts lol.DuelOptions, results *resultWriter) error {
 	c.log.Printf("Loading %s champion data ...\n", championName1)
-	lolChampion1, err := c.lolTactics.ReadChampion(getYMLPath(championName1))
+	lolChampion1, err := championRepo.ReadChampion(championName1)
 	if err != nil {
-		return fmt.Errorf("loading champion %s: %v", championName1, err)
+		return fmt.Errorf("loading champion %s: %w", championName1, err)
 	}
 
 	c.log.Printf("Loading %s champion data ...\n", championName2)
-	lolChampion2, err := c.lolTactics.ReadChampion(getYMLPath(championName2))
+	lolChampion2, err := championRepo.ReadChampion(championName2)
 	if err != nil {
-		return fmt.Errorf("loading champion %s: %v", championName2, err)
+		return fmt.Errorf("loading champion %s: %w", championName2, err)
 	}
 
+	lolChampion1, lolChampion2 = lolChampion1.Localize(c.locale), lolChampion2.Localize(c.locale)
+
 	c.log.Printf("Finding fight tactics (%s vs %s) ...\n", championName1, championName2)
-	tacticsSol := c.lolTactics.Fight(lolChampion1, lolChampion2)
+	tacticsSol, err := c.solver.Fight(lolChampion1, lolChampion2)
+	if err != nil {
+		return fmt.Errorf("finding fight tactics: %w", err)
+	}
+
+	c.log.Printf("Simulating duel (%s vs %s) ...\n", championName1, championName2)
+	duelSol := c.solver.Duel(lolChampion1, lolChampion2, duelOpts)
 
-	fileName := setFilePath(lolChampion1, lolChampion2)
-	file.Create(fileName)
-	file.Write(fileName, getRoundSpellsToString(tacticsSol.RoundOfSpells, lolChampion2.Stats.HealthPoints, tacticsSol.Benchmark))
+	content, err := getFightToString(lolChampion1, lolChampion2, tacticsSol, duelSol, results.format)
+	if err != nil {
+		return fmt.Errorf("formatting fight results: %w", err)
+	}
+
+	fileName := getFightFileName(lolChampion1, lolChampion2)
+	err = results.write(fileName, content)
+	if err != nil {
+		return fmt.Errorf("writing fight results: %w", err)
+	}
+	c.log.Printf("Fight results (%s vs %s) written to %s\n", championName1, championName2, results.destination(fileName))
 
 	return nil
 }
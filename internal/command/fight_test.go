@@ -1,7 +1,10 @@
 package command
 
 import (
+	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
@@ -13,25 +16,73 @@ import (
 
 func TestChampionsFight(t *testing.T) {
 	t.Run("fail first Read", func(t *testing.T) {
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("ReadChampion", mock.AnythingOfType("string")).Return(lol.Champion{}, errors.New("some error"))
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Return(lol.Champion{}, errors.New("some error"))
 
-		ctrl := New(&loggertest.Logger{}, nil, mockLol)
+		ctrl := New(&loggertest.Logger{}, nil, nil, nil)
 
-		err := ctrl.championsFight("mockName1", "mockName2")
+		err := ctrl.championsFight(mockRepo, "mockName1", "mockName2", lol.DuelOptions{}, newResultWriter(resultFormatText, t.TempDir(), nil))
 
 		assert.NotNil(t, err)
 	})
 
 	t.Run("fail second Read", func(t *testing.T) {
-		mockLol := &lolMocks.Tactics{}
-		mockLol.On("ReadChampion", mock.AnythingOfType("string")).Once().Return(getMockLoLChampion(), nil)
-		mockLol.On("ReadChampion", mock.AnythingOfType("string")).Once().Return(lol.Champion{}, errors.New("some error"))
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Once().Return(getMockLoLChampion(), nil)
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Once().Return(lol.Champion{}, errors.New("some error"))
 
-		ctrl := New(&loggertest.Logger{}, nil, mockLol)
+		ctrl := New(&loggertest.Logger{}, nil, nil, nil)
 
-		err := ctrl.championsFight("mockName1", "mockName2")
+		err := ctrl.championsFight(mockRepo, "mockName1", "mockName2", lol.DuelOptions{}, newResultWriter(resultFormatText, t.TempDir(), nil))
 
 		assert.NotNil(t, err)
 	})
+
+	t.Run("fail Fight", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Return(getMockLoLChampion(), nil)
+		mockSolver := &lolMocks.Solver{}
+		mockSolver.On("Fight", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.Champion")).Return(lol.TacticsSol{}, &lol.ValidationError{Champion: "mockName"})
+
+		ctrl := New(&loggertest.Logger{}, nil, nil, mockSolver)
+
+		err := ctrl.championsFight(mockRepo, "mockName1", "mockName2", lol.DuelOptions{}, newResultWriter(resultFormatText, t.TempDir(), nil))
+
+		assert.True(t, errors.Is(err, lol.ErrInvalidChampion))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", "mockName1").Return(getMockLoLChampion(), nil)
+		mockRepo.On("ReadChampion", "mockName2").Return(lol.Champion{Name: "Kai'Sa", Stats: lol.Stats{HealthPoints: 20}}, nil)
+		mockSolver := &lolMocks.Solver{}
+		mockSolver.On("Fight", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.Champion")).Return(lol.TacticsSol{Benchmark: 2, RoundOfSpells: getMockLoLChampion().Spells[:1]}, nil)
+		mockSolver.On("Duel", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.DuelOptions")).Return(lol.DuelSol{})
+		dir := filepath.Join(t.TempDir(), "results", "json")
+
+		ctrl := New(&loggertest.Logger{}, nil, nil, mockSolver)
+
+		err := ctrl.championsFight(mockRepo, "mockName1", "mockName2", lol.DuelOptions{}, newResultWriter(resultFormatJSON, dir, nil))
+
+		assert.Nil(t, err)
+		data, err := os.ReadFile(filepath.Join(dir, "mockName_vs_KaiSa.json"))
+		assert.Nil(t, err)
+		assert.Contains(t, string(data), `"defender": "Kai'Sa"`)
+	})
+
+	t.Run("success stdout", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Return(getMockLoLChampion(), nil)
+		mockSolver := &lolMocks.Solver{}
+		mockSolver.On("Fight", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.Champion")).Return(lol.TacticsSol{Benchmark: 2, RoundOfSpells: getMockLoLChampion().Spells[:1]}, nil)
+		mockSolver.On("Duel", mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.Champion"), mock.AnythingOfType("lol.DuelOptions")).Return(lol.DuelSol{})
+		var out bytes.Buffer
+
+		ctrl := New(&loggertest.Logger{}, nil, nil, mockSolver)
+
+		err := ctrl.championsFight(mockRepo, "mockName1", "mockName2", lol.DuelOptions{}, newResultWriter(resultFormatText, stdoutOutput, &out))
+
+		assert.Nil(t, err)
+		assert.Contains(t, out.String(), "aa (Auto Attack): 10.00 (hp: 50.00 -> 40.00)\n")
+	})
 }
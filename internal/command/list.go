@@ -0,0 +1,203 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listFormatTable = "table"
+	listFormatJSON  = "json"
+)
+
+// Orders champions can be listed in: by name, or from the highest health points or attack damage
+const (
+	sortByName = "name"
+	sortByHp   = "hp"
+	sortByAd   = "ad"
+)
+
+type championList struct {
+	Patch     string            `json:"patch"`
+	Champions []championSummary `json:"champions"`
+}
+
+type championSummary struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Title        string   `json:"title"`
+	Tags         []string `json:"tags"`
+	HealthPoints float64  `json:"health_points"`
+	AttackDamage float64  `json:"attack_damage"`
+	AttackSpeed  float64  `json:"attack_speed"`
+	AttackRange  float64  `json:"attack_range"`
+	MoveSpeed    float64  `json:"move_speed"`
+}
+
+func (c *Controller) ListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"l"},
+		Short:   "list the stored champions along with their main stats",
+		Args:    cobra.ExactArgs(0),
+		Run:     c.list,
+	}
+	cmd.Flags().StringSlice("tag", nil, "only list the champions with any of the given tags (e.g. marksman,tank)")
+	cmd.Flags().String("sort", sortByName, fmt.Sprintf("order of the champions: %s, %s (highest health points first) or %s (highest attack damage first)", sortByName, sortByHp, sortByAd))
+	cmd.Flags().String("format", listFormatTable, fmt.Sprintf("output format (%s or %s)", listFormatTable, listFormatJSON))
+	return cmd
+}
+
+func (c *Controller) list(cmd *cobra.Command, args []string) {
+	format, err := getListFormatFlag(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tag")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	sortBy, err := cmd.Flags().GetString("sort")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	list, err := c.listChampions(tags, sortBy)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	if format == listFormatJSON {
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			cmd.PrintErr(err)
+			os.Exit(-1)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), getChampionListToString(list))
+	}
+}
+
+func getListFormatFlag(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	if format != listFormatTable && format != listFormatJSON {
+		return "", fmt.Errorf("unknown format %q, expected %s or %s", format, listFormatTable, listFormatJSON)
+	}
+	return format, nil
+}
+
+// listChampions Champions of the selected patch with any of the given tags (all of them if none is given), in the given order.
+// Champions that cannot be loaded are left out with a warning
+func (c *Controller) listChampions(tags []string, sortBy string) (championList, error) {
+	if sortBy != sortByName && sortBy != sortByHp && sortBy != sortByAd {
+		return championList{}, fmt.Errorf("unknown sort order %q, expected %s, %s or %s", sortBy, sortByName, sortByHp, sortByAd)
+	}
+
+	patch, err := c.championStore.ResolvePatch(c.patch)
+	if err != nil {
+		return championList{}, err
+	}
+	championRepo := c.championStore.Patch(patch)
+
+	names, err := championRepo.ListChampions()
+	if err != nil {
+		return championList{}, fmt.Errorf("listing champions: %v", err)
+	}
+
+	list := championList{Patch: patch, Champions: []championSummary{}}
+	for _, name := range names {
+		champion, err := championRepo.ReadChampion(name)
+		if err != nil {
+			c.log.Warningf("Could not load %s champion data: %v", name, err)
+			continue
+		}
+		summary := getChampionSummary(champion.Localize(c.locale))
+		if hasAnyTag(summary, tags) {
+			list.Champions = append(list.Champions, summary)
+		}
+	}
+
+	sort.SliceStable(list.Champions, func(i, j int) bool {
+		c1, c2 := list.Champions[i], list.Champions[j]
+		switch {
+		case sortBy == sortByHp && c1.HealthPoints != c2.HealthPoints:
+			return c1.HealthPoints > c2.HealthPoints
+		case sortBy == sortByAd && c1.AttackDamage != c2.AttackDamage:
+			return c1.AttackDamage > c2.AttackDamage
+		default:
+			return c1.Name < c2.Name
+		}
+	})
+
+	return list, nil
+}
+
+func getChampionSummary(champion lol.Champion) championSummary {
+	tags := []string{}
+	for _, tag := range strings.Split(champion.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return championSummary{
+		ID:           champion.ID,
+		Name:         champion.Name,
+		Title:        champion.Title,
+		Tags:         tags,
+		HealthPoints: champion.Stats.HealthPoints,
+		AttackDamage: champion.Stats.AttackDamage,
+		AttackSpeed:  champion.Stats.AttackSpeed,
+		AttackRange:  champion.Stats.AttackRange,
+		MoveSpeed:    champion.Stats.MoveSpeed,
+	}
+}
+
+// hasAnyTag Whether the champion has any of the tags (whatever the case), true if no tag is given
+func hasAnyTag(champion championSummary, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		for _, championTag := range champion.Tags {
+			if strings.EqualFold(strings.TrimSpace(tag), championTag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getChampionListToString(list championList) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTAGS\tHP\tAD\tAS\tRANGE\tMS")
+	for _, champion := range list.Champions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", champion.Name, strings.Join(champion.Tags, ", "), formatNumber(champion.HealthPoints),
+			formatNumber(champion.AttackDamage), formatNumber(champion.AttackSpeed), formatNumber(champion.AttackRange), formatNumber(champion.MoveSpeed))
+	}
+	_ = w.Flush()
+
+	return sb.String() + fmt.Sprintf("\n%d champions of patch %s\n", len(list.Champions), list.Patch)
+}
+
+// formatNumber Number with as many decimals as needed (e.g. 655, 0.625)
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
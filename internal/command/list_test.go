@@ -0,0 +1,100 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListChampions(t *testing.T) {
+	mockStore := &lolMocks.PatchStore{}
+	mockStore.On("ResolvePatch", "").Return("14.20.1", nil)
+	mockStore.On("Patch", "14.20.1").Return(lol.NewMemoryRepository(
+		getMockLoLChampion(withName("Jhin"), withTags("Marksman, Mage"), withStats(lol.Stats{HealthPoints: 655, AttackDamage: 59})),
+		getMockLoLChampion(withName("Ahri"), withTags("Mage, Assassin"), withStats(lol.Stats{HealthPoints: 590, AttackDamage: 53})),
+		getMockLoLChampion(withName("Garen"), withTags("Fighter, Tank"), withStats(lol.Stats{HealthPoints: 690, AttackDamage: 66})),
+	))
+	ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+	t.Run("all by name", func(t *testing.T) {
+		list, err := ctrl.listChampions(nil, sortByName)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "14.20.1", list.Patch)
+		assert.Equal(t, []string{"Ahri", "Garen", "Jhin"}, getSummaryNames(list))
+		assert.Equal(t, championSummary{ID: "Jhin", Name: "Jhin", Title: "mockTitle", Tags: []string{"Marksman", "Mage"}, HealthPoints: 655, AttackDamage: 59}, list.Champions[2])
+	})
+
+	t.Run("by hp", func(t *testing.T) {
+		list, err := ctrl.listChampions(nil, sortByHp)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Garen", "Jhin", "Ahri"}, getSummaryNames(list))
+	})
+
+	t.Run("by ad", func(t *testing.T) {
+		list, err := ctrl.listChampions(nil, sortByAd)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Garen", "Jhin", "Ahri"}, getSummaryNames(list))
+	})
+
+	t.Run("by tag", func(t *testing.T) {
+		list, err := ctrl.listChampions([]string{"mage"}, sortByAd)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Jhin", "Ahri"}, getSummaryNames(list))
+	})
+
+	t.Run("by any of the tags", func(t *testing.T) {
+		list, err := ctrl.listChampions([]string{"tank", "assassin"}, sortByName)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Ahri", "Garen"}, getSummaryNames(list))
+	})
+
+	t.Run("no champion with the tag", func(t *testing.T) {
+		list, err := ctrl.listChampions([]string{"support"}, sortByName)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []championSummary{}, list.Champions)
+	})
+
+	t.Run("unknown sort order", func(t *testing.T) {
+		_, err := ctrl.listChampions(nil, "armor")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("fail ResolvePatch", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "").Return("", lol.ErrPatchNotFound)
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		_, err := ctrl.listChampions(nil, sortByName)
+
+		assert.True(t, errors.Is(err, lol.ErrPatchNotFound))
+	})
+}
+
+func TestGetChampionListToString(t *testing.T) {
+	list := championList{Patch: "14.20.1", Champions: []championSummary{
+		{Name: "Jhin", Tags: []string{"Marksman", "Mage"}, HealthPoints: 655, AttackDamage: 59, AttackSpeed: 0.625, AttackRange: 550, MoveSpeed: 330},
+	}}
+
+	assert.Equal(t, "NAME  TAGS            HP   AD  AS     RANGE  MS\n"+
+		"Jhin  Marksman, Mage  655  59  0.625  550    330\n"+
+		"\n1 champions of patch 14.20.1\n", getChampionListToString(list))
+}
+
+func getSummaryNames(list championList) []string {
+	names := make([]string, len(list.Champions))
+	for i, champion := range list.Champions {
+		names[i] = champion.Name
+	}
+	return names
+}
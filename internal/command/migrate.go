@@ -0,0 +1,132 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+func (c *Controller) MigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate [patch...]",
+		Aliases: []string{"m"},
+		Short:   fmt.Sprintf("rewrite the champion files of the given patches (all of them if none is given) to the current schema version (%s)", lol.CurrentSchemaVersion),
+		Run:     c.migrate,
+	}
+	cmd.Flags().Bool("dry-run", false, "only list the champion files to migrate, without rewriting them")
+	return cmd
+}
+
+func (c *Controller) migrate(cmd *cobra.Command, args []string) {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	err = c.migratePatches(args, dryRun)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+}
+
+// migratePatches Rewrite the champion files of an older schema version of the given patches (each one resolved as for --patch), all of them if none is given
+func (c *Controller) migratePatches(patches []string, dryRun bool) error {
+	// Overrides are written by hand against the current schema, only the downloaded data is migrated
+	championStore := lol.WithoutOverrides(c.championStore)
+
+	if len(patches) == 0 {
+		stored, err := championStore.ListPatches()
+		if err != nil {
+			return fmt.Errorf("listing patches: %v", err)
+		}
+		patches = stored
+	}
+
+	migrated, failed := 0, 0
+	for _, patch := range patches {
+		resolved, err := championStore.ResolvePatch(patch)
+		if err != nil {
+			return err
+		}
+
+		n, err := c.migrateChampions(championStore.Patch(resolved), dryRun)
+		migrated += n
+		if err != nil {
+			c.log.Warningf("Could not migrate patch %s: %v", resolved, err)
+			failed++
+		}
+	}
+
+	if dryRun {
+		c.log.Printf("%d champion files to migrate to schema version %s", migrated, lol.CurrentSchemaVersion)
+	} else {
+		c.log.Printf("%d champion files migrated to schema version %s", migrated, lol.CurrentSchemaVersion)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d patches could not be fully migrated", failed, len(patches))
+	}
+	return nil
+}
+
+// migrateChampions Rewrite the champion files of an older schema version, returning how many were (or would be, on dry run) migrated
+func (c *Controller) migrateChampions(championRepo lol.ChampionRepository, dryRun bool) (int, error) {
+	fileReader, ok := championRepo.(lol.ChampionFileReader)
+	if !ok {
+		return 0, fmt.Errorf("champion files cannot be read from %T", championRepo)
+	}
+
+	names, err := championRepo.ListChampions()
+	if err != nil {
+		return 0, fmt.Errorf("listing champions: %v", err)
+	}
+
+	var failed []string
+	migrated := 0
+	for _, name := range names {
+		data, err := fileReader.ReadChampionFile(name)
+		if err != nil {
+			return migrated, err
+		}
+		version, err := lol.ChampionFileVersion(data)
+		if err != nil {
+			c.log.Warningf("Could not read schema version of %s: %v", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		if version == lol.CurrentSchemaVersion {
+			continue
+		}
+
+		// Only the strict decoding can fail, the values the migration leaves untouched are not validated
+		champion, err := lol.MigrateChampionFile(name, data)
+		if err != nil {
+			c.log.Warningf("Could not migrate %s from schema version %s: %v", name, version, err)
+			failed = append(failed, name)
+			continue
+		}
+		if storedName := lol.StoredChampionName(champion); storedName != name {
+			c.log.Warningf("Could not migrate %s from schema version %s: it would be stored as %s", name, version, storedName)
+			failed = append(failed, name)
+			continue
+		}
+
+		if !dryRun {
+			err = championRepo.WriteChampion(champion)
+			if err != nil {
+				return migrated, fmt.Errorf("writing %s: %v", name, err)
+			}
+		}
+		c.log.Printf("%s: schema version %s -> %s", name, version, lol.CurrentSchemaVersion)
+		migrated++
+	}
+
+	if len(failed) > 0 {
+		return migrated, fmt.Errorf("%d champions could not be migrated: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return migrated, nil
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigratePatches(t *testing.T) {
+	writeChampionFiles := func(t *testing.T, dir string, files map[string]string) {
+		assert.Nil(t, os.MkdirAll(dir, 0700))
+		for name, data := range files {
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0600))
+		}
+	}
+	legacyJhin := "version: 1.0.0\nname: Jhin\nstats:\n  hp: 655\n  spell_block: 30\nspells:\n- {id: aa, max_rank: 1, damage: [59], cooldown: [1.6]}\n"
+	unversionedLux := "id: Lux\nname: Lux\nstats:\n  health_points: 580\nspells:\n- {id: aa, max_rank: 1, damage: [54], cooldown: [1.5]}\n"
+	currentAhri := "version: 2.0.0\nid: Ahri\nname: Ahri\nstats:\n  health_points: 590\nspells:\n- {id: aa, max_rank: 1, damage: [53], cooldown: [1.5]}\n"
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		writeChampionFiles(t, filepath.Join(dir, "14.19.1"), map[string]string{"jhin.yml": legacyJhin, "lux.yml": unversionedLux, "ahri.yml": currentAhri})
+		writeChampionFiles(t, filepath.Join(dir, "14.20.1"), map[string]string{"jhin.yml": legacyJhin})
+		store := lol.NewDirPatchStore(dir)
+
+		ctrl := New(&loggertest.Logger{}, nil, store, nil)
+
+		err := ctrl.migratePatches(nil, false)
+
+		assert.Nil(t, err)
+		for _, patch := range []string{"14.19.1", "14.20.1"} {
+			data, err := os.ReadFile(filepath.Join(dir, patch, "jhin.yml"))
+			assert.Nil(t, err)
+			assert.Contains(t, string(data), "version: "+lol.CurrentSchemaVersion+"\n")
+			assert.Contains(t, string(data), "health_points: 655")
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "14.19.1", "lux.yml"))
+		assert.Nil(t, err)
+		assert.Contains(t, string(data), "version: "+lol.CurrentSchemaVersion+"\n")
+		assert.Contains(t, string(data), "health_points: 580")
+		data, err = os.ReadFile(filepath.Join(dir, "14.19.1", "ahri.yml"))
+		assert.Nil(t, err)
+		assert.Equal(t, currentAhri, string(data)) // already current, untouched
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		dir := t.TempDir()
+		writeChampionFiles(t, filepath.Join(dir, "14.20.1"), map[string]string{"jhin.yml": legacyJhin})
+
+		ctrl := New(&loggertest.Logger{}, nil, lol.NewDirPatchStore(dir), nil)
+
+		err := ctrl.migratePatches([]string{"14.20"}, true)
+
+		assert.Nil(t, err)
+		data, err := os.ReadFile(filepath.Join(dir, "14.20.1", "jhin.yml"))
+		assert.Nil(t, err)
+		assert.Equal(t, legacyJhin, string(data))
+	})
+
+	t.Run("undecodable once migrated", func(t *testing.T) {
+		dir := t.TempDir()
+		writeChampionFiles(t, filepath.Join(dir, "14.20.1"), map[string]string{
+			"jhin.yml": "version: 1.0.0\nname: Jhin\nstats:\n  hp: 655\n  armour: 30\n",
+			"zoe.yml":  "version: 9.0.0\nid: Zoe\n",
+		})
+
+		ctrl := New(&loggertest.Logger{}, nil, lol.NewDirPatchStore(dir), nil)
+
+		err := ctrl.migratePatches(nil, false)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("unknown patch", func(t *testing.T) {
+		ctrl := New(&loggertest.Logger{}, nil, lol.NewDirPatchStore(t.TempDir()), nil)
+
+		err := ctrl.migratePatches([]string{"14.20"}, false)
+
+		assert.NotNil(t, err)
+	})
+}
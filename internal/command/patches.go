@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func (c *Controller) PatchesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "patches",
+		Aliases: []string{"p"},
+		Short:   "manage the patches champions data are stored for",
+	}
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune [patch...]",
+		Short: "remove the given patches, or all but the latest ones if none is given",
+		Run:   c.prunePatches,
+	}
+	pruneCmd.Flags().Int("keep", 1, "number of latest patches to keep when no patch is given")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list the stored patches, from the oldest to the latest",
+		Args:    cobra.ExactArgs(0),
+		Run:     c.listPatches,
+	})
+	cmd.AddCommand(pruneCmd)
+
+	return cmd
+}
+
+func (c *Controller) listPatches(cmd *cobra.Command, args []string) {
+	patches, err := c.championStore.ListPatches()
+	if err != nil {
+		cmd.PrintErrf("listing patches: %v", err)
+		os.Exit(-1)
+	}
+
+	for _, patch := range patches {
+		fmt.Fprintln(cmd.OutOrStdout(), patch)
+	}
+}
+
+func (c *Controller) prunePatches(cmd *cobra.Command, args []string) {
+	keep, err := cmd.Flags().GetInt("keep")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	err = c.removePatches(args, keep)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+}
+
+// removePatches Remove the given patches (each one resolved as for --patch), or all but the keep latest ones if none is given
+func (c *Controller) removePatches(patches []string, keep int) error {
+	if len(patches) == 0 {
+		if keep < 0 {
+			return fmt.Errorf("cannot keep %d patches", keep)
+		}
+
+		stored, err := c.championStore.ListPatches()
+		if err != nil {
+			return fmt.Errorf("listing patches: %v", err)
+		}
+		if len(stored) <= keep {
+			c.log.Printf("Nothing to prune, %d patches stored", len(stored))
+			return nil
+		}
+		patches = stored[:len(stored)-keep]
+	}
+
+	for _, patch := range patches {
+		resolved, err := c.championStore.ResolvePatch(patch)
+		if err != nil {
+			return err
+		}
+
+		err = c.championStore.RemovePatch(resolved)
+		if err != nil {
+			return fmt.Errorf("removing patch %s: %v", resolved, err)
+		}
+
+		c.log.Printf("Patch %s removed", resolved)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovePatches(t *testing.T) {
+	t.Run("given patches", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "14.19").Once().Return("14.19.1", nil)
+		mockStore.On("RemovePatch", "14.19.1").Once().Return(nil)
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		err := ctrl.removePatches([]string{"14.19"}, 1)
+
+		assert.Nil(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("keep latest", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ListPatches").Once().Return([]string{"14.18.1", "14.19.1", "14.20.1"}, nil)
+		mockStore.On("ResolvePatch", "14.18.1").Once().Return("14.18.1", nil)
+		mockStore.On("RemovePatch", "14.18.1").Once().Return(nil)
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		err := ctrl.removePatches(nil, 2)
+
+		assert.Nil(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ListPatches").Once().Return([]string{"14.20.1"}, nil)
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		err := ctrl.removePatches(nil, 1)
+
+		assert.Nil(t, err)
+		mockStore.AssertNotCalled(t, "RemovePatch")
+	})
+
+	t.Run("fail ResolvePatch", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "14.1").Once().Return("", errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		err := ctrl.removePatches([]string{"14.1"}, 1)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestChampionRepository(t *testing.T) {
+	t.Run("selected patch", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "14.20").Once().Return("14.20.1", nil)
+		mockStore.On("Patch", "14.20.1").Once().Return(mockRepo)
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+		ctrl.SetPatch("14.20")
+
+		championRepo, err := ctrl.championRepository()
+
+		assert.Nil(t, err)
+		assert.Equal(t, mockRepo, championRepo)
+	})
+
+	t.Run("fail ResolvePatch", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "").Once().Return("", errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		_, err := ctrl.championRepository()
+
+		assert.NotNil(t, err)
+	})
+}
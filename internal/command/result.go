@@ -0,0 +1,291 @@
+package command
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/internal/file"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+// Formats fight results can be written in
+const (
+	resultFormatText     = "text"
+	resultFormatJSON     = "json"
+	resultFormatMarkdown = "markdown"
+	resultFormatCSV      = "csv"
+)
+
+const (
+	defaultResultsDir = "fights" // directory fight results are written to, relative to the working directory
+	stdoutOutput      = "-"      // --output writing fight results to stdout rather than to files
+)
+
+// fightResultVersion Version of the fight result fields (JSON and CSV), bumped whenever they change in a non backward compatible way
+const fightResultVersion = "1.0.0"
+
+// fightResult Fight tactics of the attacker against the defender, along with the duel between them
+type fightResult struct {
+	Version   string      `json:"version"`
+	Attacker  string      `json:"attacker"`
+	Defender  string      `json:"defender"`
+	Patch     string      `json:"patch"`     // data dragon version the champions data was generated from, if known
+	Benchmark float64     `json:"benchmark"` // time (in seconds) taken by the attacker to kill the defender
+	Steps     []fightStep `json:"steps"`
+	Duel      duelResult  `json:"duel"`
+}
+
+// fightStep Spell of the attacker's best round of spells
+type fightStep struct {
+	Spell     string  `json:"spell"`
+	Rank      int     `json:"rank"`
+	Damage    float64 `json:"damage"`
+	HpBefore  float64 `json:"hp_before"` // defender health points before the spell
+	HpAfter   float64 `json:"hp_after"`
+	Timestamp float64 `json:"timestamp"` // time (in seconds since the fight started) the spell has been cast at
+}
+
+type duelResult struct {
+	Winner   string       `json:"winner"` // empty in case of draw
+	Duration float64      `json:"duration"`
+	Sides    [2]duelTally `json:"sides"`
+}
+
+type duelTally struct {
+	Champion     string       `json:"champion"`
+	Actions      []duelAction `json:"actions"`
+	DamageDealt  float64      `json:"damage_dealt"`
+	CCApplied    float64      `json:"cc_applied"`
+	TimeMoving   float64      `json:"time_moving"`
+	HealthPoints float64      `json:"health_points"` // health points left at the end of the duel
+}
+
+type duelAction struct {
+	Timestamp float64 `json:"timestamp"`
+	Spell     string  `json:"spell"`
+	Rank      int     `json:"rank"`
+	Damage    float64 `json:"damage"`
+	EnemyHp   float64 `json:"enemy_hp"` // enemy health points after the spell
+	Distance  float64 `json:"distance"`
+}
+
+// resultWriter Where fight results go: one file each in a directory, or all of them to stdout
+type resultWriter struct {
+	format  string
+	dir     string    // directory results are written to, empty if written to out
+	out     io.Writer // stdout
+	mu      sync.Mutex
+	written bool // whether a result has already been written to out
+}
+
+func newResultWriter(format, output string, out io.Writer) *resultWriter {
+	if output == stdoutOutput {
+		return &resultWriter{format: format, out: out}
+	}
+	return &resultWriter{format: format, dir: output}
+}
+
+// write Write the result to the file of the given name (without extension, see getFightFileName) inside the directory, creating it if
+// needed, or to stdout. Results can be written concurrently: on stdout they are written one at a time, separated by an empty line (or,
+// for CSV, only keeping the header of the first one)
+func (w *resultWriter) write(name, content string) error {
+	if w.dir != "" {
+		return file.Write(w.destination(name), content)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.written {
+		if w.format == resultFormatCSV {
+			content = content[strings.Index(content, "\n")+1:]
+		} else {
+			content = "\n" + content
+		}
+	}
+	w.written = true
+
+	_, err := fmt.Fprint(w.out, content)
+	return err
+}
+
+// destination File the result of the given name is written to, stdout if none
+func (w *resultWriter) destination(name string) string {
+	if w.dir == "" {
+		return "stdout"
+	}
+	return filepath.Join(w.dir, name+"."+resultFileExtension(w.format))
+}
+
+func addResultOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().String("output", defaultResultsDir, fmt.Sprintf("directory fight results are written to (created if missing), %s to print them to stdout instead", stdoutOutput))
+}
+
+// getResultWriter Writer of the fight results in the given format, to the --output directory or stdout
+func getResultWriter(cmd *cobra.Command, format string) (*resultWriter, error) {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, fmt.Errorf("empty output, expected a directory or %s for stdout", stdoutOutput)
+	}
+	return newResultWriter(format, output, cmd.OutOrStdout()), nil
+}
+
+// safeFileName Name with letters and digits only, so that it can be part of a file name whatever the champion (e.g. KaiSa for Kai'Sa,
+// TwistedFate for Twisted Fate)
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+func addResultFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().String("format", resultFormatText, fmt.Sprintf("format fight results are written in (%s, %s, %s or %s)", resultFormatText, resultFormatJSON, resultFormatMarkdown, resultFormatCSV))
+}
+
+func getResultFormatFlag(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case resultFormatText, resultFormatJSON, resultFormatMarkdown, resultFormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected %s, %s, %s or %s", format, resultFormatText, resultFormatJSON, resultFormatMarkdown, resultFormatCSV)
+	}
+}
+
+// resultFileExtension Extension of the files fight results of the given format are written to
+func resultFileExtension(format string) string {
+	switch format {
+	case resultFormatJSON:
+		return "json"
+	case resultFormatMarkdown:
+		return "md"
+	case resultFormatCSV:
+		return "csv"
+	default:
+		return "loltactics"
+	}
+}
+
+func newFightResult(attacker, defender lol.Champion, tacticsSol lol.TacticsSol, duelSol lol.DuelSol) fightResult {
+	result := fightResult{
+		Version:   fightResultVersion,
+		Attacker:  attacker.Name,
+		Defender:  defender.Name,
+		Patch:     attacker.Patch,
+		Benchmark: tacticsSol.Benchmark,
+		Steps:     []fightStep{},
+		Duel:      duelResult{Winner: duelSol.Winner, Duration: duelSol.Duration},
+	}
+
+	hp := defender.Stats.HealthPoints
+	times := lol.SpellTimes(tacticsSol.RoundOfSpells)
+	for i, spell := range tacticsSol.RoundOfSpells {
+		damage := spell.Damage[spell.MaxRank-1]
+		result.Steps = append(result.Steps, fightStep{Spell: spell.ID, Rank: spell.MaxRank, Damage: damage, HpBefore: hp, HpAfter: hp - damage, Timestamp: times[i]})
+		hp -= damage
+	}
+
+	for i, side := range duelSol.Sides {
+		tally := duelTally{Champion: side.Champion, Actions: []duelAction{}, DamageDealt: side.DamageDealt, CCApplied: side.CCApplied, TimeMoving: side.TimeMoving, HealthPoints: side.HealthPoints}
+		for _, a := range side.Actions {
+			tally.Actions = append(tally.Actions, duelAction{Timestamp: a.Time, Spell: a.Spell.ID, Rank: a.Spell.MaxRank, Damage: a.Spell.Damage[a.Spell.MaxRank-1], EnemyHp: a.EnemyHp, Distance: a.Distance})
+		}
+		result.Duel.Sides[i] = tally
+	}
+
+	return result
+}
+
+// getFightToString Fight tactics and duel between the two champions, in the given format
+func getFightToString(attacker, defender lol.Champion, tacticsSol lol.TacticsSol, duelSol lol.DuelSol, format string) (string, error) {
+	if format == resultFormatText {
+		return getRoundSpellsToString(tacticsSol.RoundOfSpells, defender.Stats.HealthPoints, tacticsSol.Benchmark) + getDuelToString(duelSol), nil
+	}
+
+	result := newFightResult(attacker, defender, tacticsSol, duelSol)
+	switch format {
+	case resultFormatJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case resultFormatMarkdown:
+		return getFightResultToMarkdown(result), nil
+	case resultFormatCSV:
+		return getFightResultToCSV(result)
+	default:
+		return "", fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func getFightResultToMarkdown(result fightResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s vs %s\n\n", result.Attacker, result.Defender)
+	if result.Patch != "" {
+		fmt.Fprintf(&sb, "Patch %s. ", result.Patch)
+	}
+	fmt.Fprintf(&sb, "%s defeated in %.2fs.\n\n", result.Defender, result.Benchmark)
+
+	sb.WriteString("| # | Spell | Rank | Damage | HP before | HP after | Time |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|\n")
+	for i, step := range result.Steps {
+		fmt.Fprintf(&sb, "| %d | %s | %d | %.2f | %.2f | %.2f | %.2fs |\n", i+1, step.Spell, step.Rank, step.Damage, step.HpBefore, step.HpAfter, step.Timestamp)
+	}
+
+	sb.WriteString("\n## Duel\n\n")
+	sb.WriteString("| Time | Champion | Spell | Damage | Enemy HP | Distance |\n")
+	sb.WriteString("|---|---|---|---|---|---|\n")
+	for _, side := range result.Duel.Sides {
+		for _, a := range side.Actions {
+			fmt.Fprintf(&sb, "| %.2fs | %s | %s | %.2f | %.2f | %.2f |\n", a.Timestamp, side.Champion, a.Spell, a.Damage, a.EnemyHp, a.Distance)
+		}
+	}
+	sb.WriteString("\n")
+	for _, side := range result.Duel.Sides {
+		fmt.Fprintf(&sb, "- %s: damage dealt %.2f, CC applied %.2fs, time moving %.2fs, hp left %.2f\n", side.Champion, side.DamageDealt, side.CCApplied, side.TimeMoving, side.HealthPoints)
+	}
+	if result.Duel.Winner != "" {
+		fmt.Fprintf(&sb, "\n%s won the duel in %.2fs.\n", result.Duel.Winner, result.Duel.Duration)
+	} else {
+		fmt.Fprintf(&sb, "\nDuel ended in a draw after %.2fs.\n", result.Duel.Duration)
+	}
+
+	return sb.String()
+}
+
+// getFightResultToCSV Steps of the fight tactics, one per row. Each row repeats the attacker, defender and patch, so that the results
+// of several fights can be concatenated (leaving out the header of all but the first)
+func getFightResultToCSV(result fightResult) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"version", "attacker", "defender", "patch", "benchmark", "step", "spell", "rank", "damage", "hp_before", "hp_after", "timestamp"}}
+	for i, step := range result.Steps {
+		rows = append(rows, []string{result.Version, result.Attacker, result.Defender, result.Patch, formatNumber(result.Benchmark), strconv.Itoa(i + 1),
+			step.Spell, strconv.Itoa(step.Rank), formatNumber(step.Damage), formatNumber(step.HpBefore), formatNumber(step.HpAfter), formatNumber(step.Timestamp)})
+	}
+
+	err := w.WriteAll(rows)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFightResult(t *testing.T) {
+	q := lol.Spell{ID: "q", Name: "QName", MaxRank: 2, Damage: []float64{6, 10}, Cooldown: []float64{4, 3}, Cast: 0.5}
+	aa := lol.Spell{ID: "aa", MaxRank: 1, Damage: []float64{8}, Cooldown: []float64{1}}
+	attacker := getMockLoLChampion(withName("Name1"), withPatch("14.20.1"), withSpells(aa, q))
+	defender := getMockLoLChampion(withName("Name2"), withStats(lol.Stats{HealthPoints: 25}))
+	tacticsSol := lol.TacticsSol{Benchmark: 4, RoundOfSpells: []lol.Spell{q, aa, q}}
+	duelSol := lol.DuelSol{
+		Winner:   "Name1",
+		Duration: 1,
+		Sides: [2]lol.DuelSide{
+			{Champion: "Name1", Actions: []lol.DuelAction{{Time: 0.5, Spell: q, EnemyHp: 15, Distance: 100}}, DamageDealt: 10, HealthPoints: 20},
+			{Champion: "Name2", DamageDealt: 0, HealthPoints: 15},
+		},
+	}
+
+	t.Run("new", func(t *testing.T) {
+		result := newFightResult(attacker, defender, tacticsSol, duelSol)
+
+		assert.Equal(t, fightResultVersion, result.Version)
+		assert.Equal(t, "Name1", result.Attacker)
+		assert.Equal(t, "Name2", result.Defender)
+		assert.Equal(t, "14.20.1", result.Patch)
+		assert.Equal(t, 4.0, result.Benchmark)
+		assert.Equal(t, []fightStep{
+			{Spell: "q", Rank: 2, Damage: 10, HpBefore: 25, HpAfter: 15, Timestamp: 0.5},
+			{Spell: "aa", Rank: 1, Damage: 8, HpBefore: 15, HpAfter: 7, Timestamp: 0.5},
+			{Spell: "q", Rank: 2, Damage: 10, HpBefore: 7, HpAfter: -3, Timestamp: 4}, // waits for the q cooldown, then casts it again
+		}, result.Steps)
+		assert.Equal(t, duelResult{
+			Winner:   "Name1",
+			Duration: 1,
+			Sides: [2]duelTally{
+				{Champion: "Name1", Actions: []duelAction{{Timestamp: 0.5, Spell: "q", Rank: 2, Damage: 10, EnemyHp: 15, Distance: 100}}, DamageDealt: 10, HealthPoints: 20},
+				{Champion: "Name2", Actions: []duelAction{}, HealthPoints: 15},
+			},
+		}, result.Duel)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		fightToString, err := getFightToString(attacker, defender, tacticsSol, duelSol, resultFormatText)
+
+		assert.Nil(t, err)
+		assert.Equal(t, getRoundSpellsToString(tacticsSol.RoundOfSpells, 25, 4)+getDuelToString(duelSol), fightToString)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		fightToString, err := getFightToString(attacker, defender, tacticsSol, duelSol, resultFormatJSON)
+		assert.Nil(t, err)
+
+		var result fightResult
+		assert.Nil(t, json.Unmarshal([]byte(fightToString), &result))
+		assert.Equal(t, newFightResult(attacker, defender, tacticsSol, duelSol), result)
+		assert.Contains(t, fightToString, `"hp_before": 25`)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		fightToString, err := getFightToString(attacker, defender, tacticsSol, duelSol, resultFormatMarkdown)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "# Name1 vs Name2\n\n"+
+			"Patch 14.20.1. Name2 defeated in 4.00s.\n\n"+
+			"| # | Spell | Rank | Damage | HP before | HP after | Time |\n"+
+			"|---|---|---|---|---|---|---|\n"+
+			"| 1 | q | 2 | 10.00 | 25.00 | 15.00 | 0.50s |\n"+
+			"| 2 | aa | 1 | 8.00 | 15.00 | 7.00 | 0.50s |\n"+
+			"| 3 | q | 2 | 10.00 | 7.00 | -3.00 | 4.00s |\n"+
+			"\n## Duel\n\n"+
+			"| Time | Champion | Spell | Damage | Enemy HP | Distance |\n"+
+			"|---|---|---|---|---|---|\n"+
+			"| 0.50s | Name1 | q | 10.00 | 15.00 | 100.00 |\n"+
+			"\n"+
+			"- Name1: damage dealt 10.00, CC applied 0.00s, time moving 0.00s, hp left 20.00\n"+
+			"- Name2: damage dealt 0.00, CC applied 0.00s, time moving 0.00s, hp left 15.00\n"+
+			"\nName1 won the duel in 1.00s.\n", fightToString)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		fightToString, err := getFightToString(attacker, defender, tacticsSol, duelSol, resultFormatCSV)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "version,attacker,defender,patch,benchmark,step,spell,rank,damage,hp_before,hp_after,timestamp\n"+
+			"1.0.0,Name1,Name2,14.20.1,4,1,q,2,10,25,15,0.5\n"+
+			"1.0.0,Name1,Name2,14.20.1,4,2,aa,1,8,15,7,0.5\n"+
+			"1.0.0,Name1,Name2,14.20.1,4,3,q,2,10,7,-3,4\n", fightToString)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := getFightToString(attacker, defender, tacticsSol, duelSol, "xml")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestResultFileExtension(t *testing.T) {
+	assert.Equal(t, "loltactics", resultFileExtension(resultFormatText))
+	assert.Equal(t, "json", resultFileExtension(resultFormatJSON))
+	assert.Equal(t, "md", resultFileExtension(resultFormatMarkdown))
+	assert.Equal(t, "csv", resultFileExtension(resultFormatCSV))
+}
+
+func TestResultWriter(t *testing.T) {
+	t.Run("directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "missing", "results")
+		w := newResultWriter(resultFormatMarkdown, dir, nil)
+
+		err := w.write("Name1_vs_Name2", "# Name1 vs Name2\n")
+
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(dir, "Name1_vs_Name2.md"), w.destination("Name1_vs_Name2"))
+		data, err := os.ReadFile(filepath.Join(dir, "Name1_vs_Name2.md"))
+		assert.Nil(t, err)
+		assert.Equal(t, "# Name1 vs Name2\n", string(data))
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		var out bytes.Buffer
+		w := newResultWriter(resultFormatText, stdoutOutput, &out)
+
+		assert.Nil(t, w.write("Name1_vs_Name2", "first\n"))
+		assert.Nil(t, w.write("Name2_vs_Name1", "second\n"))
+
+		assert.Equal(t, "first\n\nsecond\n", out.String())
+	})
+
+	t.Run("stdout csv", func(t *testing.T) {
+		var out bytes.Buffer
+		w := newResultWriter(resultFormatCSV, stdoutOutput, &out)
+
+		assert.Nil(t, w.write("Name1_vs_Name2", "header\nrow1\n"))
+		assert.Nil(t, w.write("Name2_vs_Name1", "header\nrow2\n"))
+
+		assert.Equal(t, "header\nrow1\nrow2\n", out.String())
+	})
+}
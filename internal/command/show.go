@@ -0,0 +1,147 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+func (c *Controller) ShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show champion",
+		Aliases: []string{"s"},
+		Short:   "show the stats and the spells (rank by rank) of a stored champion",
+		Args:    cobra.ExactArgs(1),
+		Run:     c.show,
+	}
+	cmd.Flags().String("format", listFormatTable, fmt.Sprintf("output format (%s or %s, the latter with the same fields as champion files)", listFormatTable, listFormatJSON))
+	return cmd
+}
+
+func (c *Controller) show(cmd *cobra.Command, args []string) {
+	format, err := getListFormatFlag(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	champion, err := c.showChampion(args[0])
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	if format == listFormatJSON {
+		data, err := lol.MarshalChampion(champion, lol.FormatJSON)
+		if err != nil {
+			cmd.PrintErr(err)
+			os.Exit(-1)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), getChampionToString(champion))
+	}
+}
+
+// showChampion Champion of the selected patch the name refers to, in the selected locale
+func (c *Controller) showChampion(name string) (lol.Champion, error) {
+	championRepo, err := c.championRepository()
+	if err != nil {
+		return lol.Champion{}, err
+	}
+
+	names, err := resolveChampionNames(championRepo, name)
+	if err != nil {
+		return lol.Champion{}, err
+	}
+
+	champion, err := championRepo.ReadChampion(names[0])
+	if err != nil {
+		return lol.Champion{}, fmt.Errorf("loading champion %s: %w", names[0], err)
+	}
+	return champion.Localize(c.locale), nil
+}
+
+func getChampionToString(champion lol.Champion) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s, %s\n", champion.Name, champion.Title)
+	details := []string{"id: " + champion.ID, "tags: " + champion.Tags}
+	if champion.Patch != "" {
+		details = append(details, "patch: "+champion.Patch)
+	}
+	fmt.Fprintln(&sb, strings.Join(details, ", "))
+	if champion.Passive.Name != "" {
+		fmt.Fprintf(&sb, "passive: %s\n", champion.Passive.Name)
+	}
+
+	sb.WriteString("\n")
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	for _, stat := range []struct {
+		name  string
+		value float64
+	}{
+		{"health points", champion.Stats.HealthPoints},
+		{"attack damage", champion.Stats.AttackDamage},
+		{"attack speed", champion.Stats.AttackSpeed},
+		{"attack range", champion.Stats.AttackRange},
+		{"move speed", champion.Stats.MoveSpeed},
+		{"tenacity", champion.Stats.Tenacity},
+	} {
+		fmt.Fprintf(w, "%s\t%s\n", stat.name, formatNumber(stat.value))
+	}
+	_ = w.Flush()
+
+	sb.WriteString("\n")
+	w = tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SPELL\tRANK\tDAMAGE\tTYPE\tRATIOS\tCOOLDOWN\tCAST\tRANGE\tCC")
+	for _, spell := range champion.Spells {
+		for rank := 0; rank < spell.MaxRank || rank == 0; rank++ {
+			name, damageType, cast := "", "", ""
+			if rank == 0 {
+				name, damageType, cast = getSpellToString(spell), spell.DamageType, formatNumber(spell.Cast)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name, rank+1, rankValue(spell.Damage, rank), damageType, getRankRatiosToString(spell.Ratios, rank),
+				rankValue(spell.Cooldown, rank), cast, rankValue(spell.Range, rank), getRankCCToString(spell.CC, rank))
+		}
+	}
+	_ = w.Flush()
+
+	return sb.String()
+}
+
+// rankValue Value of the given spell rank (starting from 0), - if missing
+func rankValue(values []float64, rank int) string {
+	if rank >= len(values) {
+		return "-"
+	}
+	return formatNumber(values[rank])
+}
+
+func getRankRatiosToString(ratios map[string][]float64, rank int) string {
+	stats := make([]string, 0, len(ratios))
+	for stat := range ratios {
+		stats = append(stats, stat)
+	}
+	sort.Strings(stats)
+
+	var ratiosToString []string
+	for _, stat := range stats {
+		if rank < len(ratios[stat]) {
+			ratiosToString = append(ratiosToString, fmt.Sprintf("%s %s", formatNumber(ratios[stat][rank]), stat))
+		}
+	}
+	return strings.Join(ratiosToString, ", ")
+}
+
+func getRankCCToString(cc []lol.CrowdControl, rank int) string {
+	var ccToString []string
+	for _, effect := range cc {
+		ccToString = append(ccToString, fmt.Sprintf("%s %ss", effect.Type, rankValue(effect.Duration, rank)))
+	}
+	return strings.Join(ccToString, ", ")
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowChampion(t *testing.T) {
+	mockStore := &lolMocks.PatchStore{}
+	mockStore.On("ResolvePatch", "").Return("14.20.1", nil)
+	mockStore.On("Patch", "14.20.1").Return(lol.NewMemoryRepository(
+		lol.Champion{ID: "MonkeyKing", Name: "Wukong", Locales: map[string]lol.Localization{"it_IT": {Title: "il Re Scimmia"}}},
+	))
+	ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+	t.Run("success", func(t *testing.T) {
+		champion, err := ctrl.showChampion("wukong")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "MonkeyKing", champion.ID)
+	})
+
+	t.Run("localized", func(t *testing.T) {
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+		ctrl.SetLocale("it_IT")
+
+		champion, err := ctrl.showChampion("wukong")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "il Re Scimmia", champion.Title)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := ctrl.showChampion("wukomg")
+
+		assert.True(t, errors.Is(err, lol.ErrChampionNotFound))
+		assert.Contains(t, err.Error(), "did you mean Wukong?")
+	})
+}
+
+func TestGetChampionToString(t *testing.T) {
+	champion := lol.Champion{
+		ID:      "Jhin",
+		Name:    "Jhin",
+		Title:   "the Virtuoso",
+		Tags:    "Marksman, Mage",
+		Patch:   "14.20.1",
+		Passive: lol.Passive{Name: "Whisper"},
+		Stats:   lol.Stats{HealthPoints: 655, AttackDamage: 59, AttackSpeed: 0.625, AttackRange: 550, MoveSpeed: 330},
+		Spells: []lol.Spell{
+			{ID: "aa", Name: "Auto Attack", MaxRank: 1, Damage: []float64{59}, Cooldown: []float64{1.6}},
+			{
+				ID:         "JhinW",
+				Name:       "Deadly Flourish",
+				MaxRank:    2,
+				Damage:     []float64{60, 95},
+				Ratios:     map[string][]float64{"TotalADRatio": {0.5, 0.5}},
+				DamageType: lol.DamagePhysical,
+				Cooldown:   []float64{12},
+				Cast:       0.75,
+				CC:         []lol.CrowdControl{{Type: lol.CCRoot, Duration: []float64{1.25, 1.5}}},
+				Range:      []float64{2550, 2550},
+			},
+		},
+	}
+
+	assert.Equal(t, "Jhin, the Virtuoso\n"+
+		"id: Jhin, tags: Marksman, Mage, patch: 14.20.1\n"+
+		"passive: Whisper\n"+
+		"\n"+
+		"health points  655\n"+
+		"attack damage  59\n"+
+		"attack speed   0.625\n"+
+		"attack range   550\n"+
+		"move speed     330\n"+
+		"tenacity       0\n"+
+		"\n"+
+		"SPELL                    RANK  DAMAGE  TYPE      RATIOS            COOLDOWN  CAST  RANGE  CC\n"+
+		"aa (Auto Attack)         1     59                                  1.6       0     -      \n"+
+		"JhinW (Deadly Flourish)  1     60      physical  0.5 TotalADRatio  12        0.75  2550   root 1.25s\n"+
+		"                         2     95                0.5 TotalADRatio  -               2550   root 1.5s\n",
+		getChampionToString(champion))
+}
@@ -2,33 +2,46 @@ package command
 
 import (
 	"os"
-	"path/filepath"
-	"strings"
 	"sync"
 
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
 	"github.com/spf13/cobra"
 )
 
 func (c *Controller) TacticsCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "tactics",
 		Aliases: []string{"t"},
 		Short:   "generate all fight tactics",
 		Args:    cobra.ExactArgs(0),
 		Run:     c.allChampionsFight,
 	}
+	addResultFormatFlag(cmd)
+	addResultOutputFlag(cmd)
+	return cmd
 }
 
 func (c *Controller) allChampionsFight(cmd *cobra.Command, args []string) {
-	var championsName []string
-	err := filepath.Walk(baseChampionPath, func(path string, info os.FileInfo, err error) error {
-		if path != baseChampionPath {
-			championsName = append(championsName, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
-		}
-		return nil
-	})
+	format, err := getResultFormatFlag(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	results, err := getResultWriter(cmd, format)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	championRepo, err := c.championRepository()
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	championsName, err := championRepo.ListChampions()
 	if err != nil {
-		cmd.PrintErrf("listing champions data files in path %s: %v", baseChampionPath, err)
+		cmd.PrintErrf("listing champions data: %v", err)
 		os.Exit(-1)
 	}
 
@@ -41,7 +54,7 @@ func (c *Controller) allChampionsFight(cmd *cobra.Command, args []string) {
 				c2 := c2
 				go func() {
 					defer wg.Done()
-					err = c.championsFight(c1, c2)
+					err := c.championsFight(championRepo, c1, c2, lol.DuelOptions{}, results)
 					if err != nil {
 						c.log.Warningf("Could not generate fight tactics between %s vs %s: %v", c1, c2, err)
 					}
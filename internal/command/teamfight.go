@@ -0,0 +1,144 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+func (c *Controller) TeamFightCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "teamfight",
+		Aliases: []string{"tf"},
+		Short:   "league of legends team fight, up to 5v5 (e.g. teamfight ahri,jhin vs lucian,leona)",
+		Args:    cobra.ExactArgs(3),
+		Run:     c.teamFight,
+	}
+	cmd.Flags().Float64("distance", 0, "initial distance between the two teams (0 means everyone starts in range)")
+	cmd.Flags().String("focus", lol.TargetLowestHp, fmt.Sprintf("targeting policy of both teams (%s, %s or %s)", lol.TargetLowestHp, lol.TargetClosest, lol.TargetCarry))
+	cmd.Flags().String("focus1", "", "targeting policy of the first team, default to --focus")
+	cmd.Flags().String("focus2", "", "targeting policy of the second team, default to --focus")
+	addResultOutputFlag(cmd)
+	return cmd
+}
+
+func (c *Controller) teamFight(cmd *cobra.Command, args []string) {
+	team1, team2, err := parseTeams(args)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	distance, err := cmd.Flags().GetFloat64("distance")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	targeting, err := getTargetingFlags(cmd)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	results, err := getResultWriter(cmd, resultFormatText)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	championRepo, err := c.championRepository()
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	championNames, err := resolveChampionNames(championRepo, append(append([]string{}, team1...), team2...)...)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	team1, team2 = championNames[:len(team1)], championNames[len(team1):]
+
+	err = c.championsTeamFight(championRepo, team1, team2, lol.TeamFightOptions{Distance: distance, Targeting: targeting}, results)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+}
+
+// getTargetingFlags Targeting policy of each team, --focus1 and --focus2 overriding --focus for their own team
+func getTargetingFlags(cmd *cobra.Command) ([2]string, error) {
+	focus, err := cmd.Flags().GetString("focus")
+	if err != nil {
+		return [2]string{}, err
+	}
+
+	targeting := [2]string{focus, focus}
+	for i, flag := range []string{"focus1", "focus2"} {
+		teamFocus, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return [2]string{}, err
+		}
+		if teamFocus != "" {
+			targeting[i] = teamFocus
+		}
+	}
+	return targeting, nil
+}
+
+// parseTeams Parse arguments in the form of "a,b,c vs d,e,f"
+func parseTeams(args []string) (team1, team2 []string, err error) {
+	if len(args) != 3 || !strings.EqualFold(args[1], "vs") {
+		return nil, nil, fmt.Errorf("teams must be given as <champion,...> vs <champion,...>")
+	}
+
+	for i, arg := range []string{args[0], args[2]} {
+		var team []string
+		for _, name := range strings.Split(arg, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				team = append(team, strings.ToLower(name))
+			}
+		}
+		if len(team) == 0 {
+			return nil, nil, fmt.Errorf("team %d has no champions", i+1)
+		}
+		if i == 0 {
+			team1 = team
+		} else {
+			team2 = team
+		}
+	}
+
+	return team1, team2, nil
+}
+
+func (c *Controller) championsTeamFight(championRepo lol.ChampionRepository, championNames1, championNames2 []string, opts lol.TeamFightOptions, results *resultWriter) error {
+	var teams [2][]lol.Champion
+	for i, names := range [2][]string{championNames1, championNames2} {
+		for _, name := range names {
+			c.log.Printf("Loading %s champion data ...\n", name)
+			lolChampion, err := championRepo.ReadChampion(name)
+			if err != nil {
+				return fmt.Errorf("loading champion %s: %v", name, err)
+			}
+			teams[i] = append(teams[i], lolChampion.Localize(c.locale))
+		}
+	}
+
+	c.log.Printf("Simulating team fight (%s vs %s) ...\n", strings.Join(championNames1, ", "), strings.Join(championNames2, ", "))
+	teamFightSol, err := c.solver.TeamFight(teams[0], teams[1], opts)
+	if err != nil {
+		return fmt.Errorf("simulating team fight: %v", err)
+	}
+
+	fileName := getTeamFightFileName(teams[0], teams[1])
+	err = results.write(fileName, getTeamFightToString(teamFightSol))
+	if err != nil {
+		return fmt.Errorf("writing team fight results: %v", err)
+	}
+	c.log.Printf("Team fight results written to %s\n", results.destination(fileName))
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseTeams(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		team1, team2, err := parseTeams([]string{"Ahri,jhin, lucian", "vs", "Leona,"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ahri", "jhin", "lucian"}, team1)
+		assert.Equal(t, []string{"leona"}, team2)
+	})
+
+	t.Run("missing vs", func(t *testing.T) {
+		_, _, err := parseTeams([]string{"ahri", "and", "leona"})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("empty team", func(t *testing.T) {
+		_, _, err := parseTeams([]string{",", "vs", "leona"})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetTargetingFlags(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args     []string
+		expected [2]string
+	}{
+		"default":           {nil, [2]string{lol.TargetLowestHp, lol.TargetLowestHp}},
+		"both teams":        {[]string{"--focus", lol.TargetCarry}, [2]string{lol.TargetCarry, lol.TargetCarry}},
+		"per team":          {[]string{"--focus1", lol.TargetCarry, "--focus2", lol.TargetClosest}, [2]string{lol.TargetCarry, lol.TargetClosest}},
+		"one team override": {[]string{"--focus", lol.TargetClosest, "--focus2", lol.TargetCarry}, [2]string{lol.TargetClosest, lol.TargetCarry}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := New(&loggertest.Logger{}, nil, nil, nil).TeamFightCommand()
+			assert.Nil(t, cmd.ParseFlags(tc.args))
+
+			targeting, err := getTargetingFlags(cmd)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, targeting)
+		})
+	}
+}
+
+func TestChampionsTeamFight(t *testing.T) {
+	t.Run("fail Read", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Once().Return(getMockLoLChampion(), nil)
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Once().Return(lol.Champion{}, errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, nil, nil, nil)
+
+		err := ctrl.championsTeamFight(mockRepo, []string{"mockName1"}, []string{"mockName2"}, lol.TeamFightOptions{}, newResultWriter(resultFormatText, t.TempDir(), nil))
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("fail TeamFight", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Return(getMockLoLChampion(), nil)
+		mockSolver := &lolMocks.Solver{}
+		mockSolver.On("TeamFight", mock.Anything, mock.Anything, mock.AnythingOfType("lol.TeamFightOptions")).Return(lol.TeamFightSol{}, errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, nil, nil, mockSolver)
+
+		err := ctrl.championsTeamFight(mockRepo, []string{"mockName1"}, []string{"mockName2"}, lol.TeamFightOptions{}, newResultWriter(resultFormatText, t.TempDir(), nil))
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := &lolMocks.ChampionRepository{}
+		mockRepo.On("ReadChampion", mock.AnythingOfType("string")).Return(getMockLoLChampion(), nil)
+		mockSolver := &lolMocks.Solver{}
+		opts := lol.TeamFightOptions{Targeting: [2]string{lol.TargetCarry, lol.TargetClosest}}
+		mockSolver.On("TeamFight", mock.Anything, mock.Anything, opts).Return(lol.TeamFightSol{Winner: 1, Duration: 2}, nil)
+		dir := filepath.Join(t.TempDir(), "results")
+
+		ctrl := New(&loggertest.Logger{}, nil, nil, mockSolver)
+
+		err := ctrl.championsTeamFight(mockRepo, []string{"mockName1"}, []string{"mockName2"}, opts, newResultWriter(resultFormatText, dir, nil))
+
+		assert.Nil(t, err)
+		mockSolver.AssertExpectations(t)
+		data, err := os.ReadFile(filepath.Join(dir, "mockName_vs_mockName.loltactics"))
+		assert.Nil(t, err)
+		assert.Contains(t, string(data), "Team 1 won the team fight in 2.00s")
+	})
+}
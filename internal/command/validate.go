@@ -0,0 +1,137 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/spf13/cobra"
+)
+
+const (
+	validateFormatText = "text"
+	validateFormatJSON = "json"
+)
+
+type validationReport struct {
+	Patch     string               `json:"patch"`
+	Champions []championValidation `json:"champions"`
+}
+
+type championValidation struct {
+	Champion string                `json:"champion"`
+	Valid    bool                  `json:"valid"`
+	Issues   []lol.ValidationIssue `json:"issues,omitempty"`
+}
+
+func (r validationReport) valid() bool {
+	for _, champion := range r.Champions {
+		if !champion.Valid {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Controller) ValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "validate [champion...]",
+		Aliases: []string{"v"},
+		Short:   "check the champions data files (all of them if none is given) for unknown fields, missing or inconsistent values, exiting with 1 if any is invalid",
+		Args:    cobra.ArbitraryArgs,
+		Run:     c.validate,
+	}
+	cmd.Flags().String("format", validateFormatText, fmt.Sprintf("output format (%s or %s)", validateFormatText, validateFormatJSON))
+	return cmd
+}
+
+func (c *Controller) validate(cmd *cobra.Command, args []string) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+	if format != validateFormatText && format != validateFormatJSON {
+		cmd.PrintErrf("unknown format %q", format)
+		os.Exit(-1)
+	}
+
+	report, err := c.validateChampions(args)
+	if err != nil {
+		cmd.PrintErr(err)
+		os.Exit(-1)
+	}
+
+	if format == validateFormatJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			cmd.PrintErr(err)
+			os.Exit(-1)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), getValidationReportToString(report))
+	}
+
+	if !report.valid() {
+		os.Exit(1)
+	}
+}
+
+// validateChampions Validate the given champions of the selected patch, all of them if none is given
+func (c *Controller) validateChampions(names []string) (validationReport, error) {
+	patch, err := c.championStore.ResolvePatch(c.patch)
+	if err != nil {
+		return validationReport{}, err
+	}
+	championRepo := c.championStore.Patch(patch)
+
+	if len(names) == 0 {
+		names, err = championRepo.ListChampions()
+		if err != nil {
+			return validationReport{}, fmt.Errorf("listing champions of patch %s: %v", patch, err)
+		}
+	}
+
+	report := validationReport{Patch: patch, Champions: make([]championValidation, 0, len(names))}
+	for _, name := range names {
+		issues := validateChampion(championRepo, name)
+		report.Champions = append(report.Champions, championValidation{Champion: name, Valid: len(issues) == 0, Issues: issues})
+	}
+	return report, nil
+}
+
+// validateChampion Issues of the champion as loaded, i.e. strictly decoded with its override if any
+func validateChampion(championRepo lol.ChampionRepository, name string) []lol.ValidationIssue {
+	_, err := championRepo.ReadChampion(name)
+	var validationErr *lol.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Issues
+	}
+	if err != nil {
+		return []lol.ValidationIssue{{Message: err.Error()}}
+	}
+	return nil
+}
+
+func getValidationReportToString(report validationReport) string {
+	var reportToString string
+	invalid := 0
+	for _, champion := range report.Champions {
+		if !champion.Valid {
+			invalid++
+		}
+		for _, issue := range champion.Issues {
+			reportToString += fmt.Sprintf("%s: %s\n", champion.Champion, issue)
+		}
+	}
+
+	if invalid == 0 {
+		reportToString += fmt.Sprintf("All %d champions of patch %s are valid\n", len(report.Champions), report.Patch)
+	} else {
+		reportToString += fmt.Sprintf("%d of %d champions of patch %s are invalid\n", invalid, len(report.Champions), report.Patch)
+	}
+	return reportToString
+}
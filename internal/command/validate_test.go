@@ -0,0 +1,110 @@
+package command
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	lolMocks "github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChampions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "ahri.yml"), []byte(`
+id: Ahri
+name: Ahri
+stats:
+  health_points: 590
+spells:
+- id: aa
+  max_rank: 1
+  damage: [53]
+  cooldown: [1.5]
+`), 0600))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "jhin.yml"), []byte(`
+id: Jhin
+name: Jhin
+stats:
+  health_points: 655
+  atack_damage: 59
+spells:
+- id: JhinQ
+  max_rank: 5
+  damage: [45, 70, 95]
+  cooldown: [9, 7.5, 6, 4.5, 3]
+`), 0600))
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "").Return("14.20.1", nil)
+		mockStore.On("Patch", "14.20.1").Return(lol.NewDirRepository(dir))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		report, err := ctrl.validateChampions(nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "14.20.1", report.Patch)
+		assert.False(t, report.valid())
+		assert.Len(t, report.Champions, 2)
+		assert.Equal(t, championValidation{Champion: "ahri", Valid: true}, report.Champions[0])
+		assert.Equal(t, "jhin", report.Champions[1].Champion)
+		assert.False(t, report.Champions[1].Valid)
+		assert.Len(t, report.Champions[1].Issues, 3)
+		assert.Contains(t, report.Champions[1].Issues[0].Message, "atack_damage")
+		assert.Equal(t, lol.ValidationIssue{Field: "spells[JhinQ].damage", Spell: "JhinQ", Message: "has 3 values, fewer than max_rank (5)"}, report.Champions[1].Issues[1])
+		assert.Equal(t, lol.ValidationIssue{Field: "spells", Message: "missing aa (auto attack) spell"}, report.Champions[1].Issues[2])
+	})
+
+	t.Run("champion not found", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "").Return("14.20.1", nil)
+		mockStore.On("Patch", "14.20.1").Return(lol.NewDirRepository(t.TempDir()))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		report, err := ctrl.validateChampions([]string{"jhin"})
+
+		assert.Nil(t, err)
+		assert.False(t, report.valid())
+		assert.Len(t, report.Champions[0].Issues, 1)
+	})
+
+	t.Run("fail ResolvePatch", func(t *testing.T) {
+		mockStore := &lolMocks.PatchStore{}
+		mockStore.On("ResolvePatch", "").Return("", errors.New("some error"))
+
+		ctrl := New(&loggertest.Logger{}, nil, mockStore, nil)
+
+		_, err := ctrl.validateChampions(nil)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetValidationReportToString(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		report := validationReport{
+			Patch: "14.20.1",
+			Champions: []championValidation{
+				{Champion: "ahri", Valid: true},
+				{Champion: "jhin", Issues: []lol.ValidationIssue{{Field: "stats.health_points", Message: "must be positive, got 0"}, {Message: "some error"}}},
+			},
+		}
+
+		expectedString := "jhin: stats.health_points: must be positive, got 0\n" +
+			"jhin: some error\n" +
+			"1 of 2 champions of patch 14.20.1 are invalid\n"
+
+		assert.Equal(t, expectedString, getValidationReportToString(report))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		report := validationReport{Patch: "14.20.1", Champions: []championValidation{{Champion: "ahri", Valid: true}}}
+
+		assert.Equal(t, "All 1 champions of patch 14.20.1 are valid\n", getValidationReportToString(report))
+	})
+}
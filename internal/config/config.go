@@ -1,12 +1,33 @@
 package config
 
 import (
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
+	LoLPatch  string `envconfig:"LOL_PATCH"`
+	LoLLocale string `envconfig:"LOL_LOCALE"`
+	DataDir   string `envconfig:"LOL_DATA_DIR"`
+
+	OverridesDir string `envconfig:"LOL_OVERRIDES_DIR"`
+
+	CDragonSource string `envconfig:"LOL_CDRAGON_SOURCE"` // CommunityDragon URL or local mirror directory
+
+	CacheDir string        `envconfig:"LOL_CACHE_DIR"`
+	CacheTTL time.Duration `envconfig:"LOL_CACHE_TTL" default:"24h"`
+
+	HTTPTimeout    time.Duration `envconfig:"LOL_HTTP_TIMEOUT" default:"30s"`
+	HTTPRetries    int           `envconfig:"LOL_HTTP_RETRIES" default:"3"`
+	HTTPRetryDelay time.Duration `envconfig:"LOL_HTTP_RETRY_DELAY" default:"500ms"`
+	HTTPRateLimit  float64       `envconfig:"LOL_HTTP_RATE_LIMIT" default:"20"`
+
+	DownloadConcurrency int `envconfig:"LOL_DOWNLOAD_CONCURRENCY" default:"30"`
+
+	// Deprecated: Data Dragon needs neither an API key nor a region, both are only read to warn they are ignored
 	RiotAPIKey string `envconfig:"RIOT_API_KEY"`
-	LoLRegion  string `envconfig:"LOL_REGION" required:"true"`
+	LoLRegion  string `envconfig:"LOL_REGION"`
 }
 
 func ReadConfig() (*Config, error) {
@@ -17,3 +38,15 @@ func ReadConfig() (*Config, error) {
 	}
 	return &cfg, nil
 }
+
+// DeprecatedVariables Environment variables set although they are no longer used
+func (c *Config) DeprecatedVariables() []string {
+	var names []string
+	if c.RiotAPIKey != "" {
+		names = append(names, "RIOT_API_KEY")
+	}
+	if c.LoLRegion != "" {
+		names = append(names, "LOL_REGION")
+	}
+	return names
+}
@@ -2,33 +2,14 @@ package file
 
 import (
 	"os"
+	"path/filepath"
 )
 
-func Create(fileName string) {
-	fi, err := os.Create(fileName)
+// Write Write the content to the file, creating it (along with its missing parent directories) or truncating it
+func Write(fileName, content string) error {
+	err := os.MkdirAll(filepath.Dir(fileName), 0755)
 	if err != nil {
-		panic(err)
-	}
-	defer func() {
-		if err := fi.Close(); err != nil {
-			panic(err)
-		}
-	}()
-}
-
-func Write(fileName, content string) {
-	fo, err := os.Create(fileName)
-	if err != nil {
-		panic(err)
-	}
-	defer func() {
-		if err := fo.Close(); err != nil {
-			panic(err)
-		}
-	}()
-
-	_, err = fo.WriteString(content)
-	if err != nil {
-		panic(err)
+		return err
 	}
+	return os.WriteFile(fileName, []byte(content), 0644)
 }
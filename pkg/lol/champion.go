@@ -1,21 +1,18 @@
 package lol
 
-import (
-	"fmt"
-	"os"
-
-	"gopkg.in/yaml.v2"
-)
-
 // Champion LoL champion data struct
 type Champion struct {
-	ID      string  `yaml:"id"`
-	Name    string  `yaml:"name"`
-	Title   string  `yaml:"title"`
-	Tags    string  `yaml:"tags"`
-	Passive Passive `yaml:"passive"`
-	Stats   Stats   `yaml:"stats"`
-	Spells  []Spell `yaml:"spells"`
+	Version string                  `yaml:"version,omitempty"` // schema version of the file (see CurrentSchemaVersion), older files being migrated when read
+	ID      string                  `yaml:"id"`
+	Name    string                  `yaml:"name"`
+	Title   string                  `yaml:"title"`
+	Tags    string                  `yaml:"tags"`
+	Patch   string                  `yaml:"patch,omitempty"`  // data dragon version the data was generated from
+	Locale  string                  `yaml:"locale,omitempty"` // locale of the display names (e.g. en_US), DefaultLocale if missing
+	Passive Passive                 `yaml:"passive"`
+	Stats   Stats                   `yaml:"stats"`
+	Spells  []Spell                 `yaml:"spells"`
+	Locales map[string]Localization `yaml:"locales,omitempty"` // display names in other locales, by locale
 }
 
 type Passive struct {
@@ -27,41 +24,36 @@ type Stats struct {
 	HealthPoints float64 `yaml:"health_points"`
 	AttackDamage float64 `yaml:"attack_damage"`
 	AttackSpeed  float64 `yaml:"attack_speed"`
+	Tenacity     float64 `yaml:"tenacity"` // fraction (0-1) by which incoming crowd control duration is reduced
+	AttackRange  float64 `yaml:"attack_range"`
+	MoveSpeed    float64 `yaml:"move_speed"`
 }
 
 type Spell struct {
-	ID       string    `yaml:"id"`
-	Name     string    `yaml:"name"`
-	MaxRank  int       `yaml:"max_rank"`
-	Damage   []float64 `yaml:"damage"`
-	Cooldown []float64 `yaml:"cooldown"`
-	Cast     float64   `yaml:"cast"`
+	ID         string               `yaml:"id"`
+	Name       string               `yaml:"name"`
+	MaxRank    int                  `yaml:"max_rank"`
+	Damage     []float64            `yaml:"damage"`
+	Ratios     map[string][]float64 `yaml:"ratios,omitempty"`      // damage scaling per spell rank, by scaling stat (e.g. TotalADRatio), not applied to damage
+	DamageType string               `yaml:"damage_type,omitempty"` // physical, magic or true, if known
+	Confidence float64              `yaml:"confidence,omitempty"`  // how likely (0-1) damage is right, when recovered from Data Dragon tooltips
+	Cooldown   []float64            `yaml:"cooldown"`
+	Cast       float64              `yaml:"cast"`
+	CC         []CrowdControl       `yaml:"cc,omitempty"`
+	Range      []float64            `yaml:"range,omitempty"`   // range per spell rank, if missing the spell can always reach the enemy
+	Dash       float64              `yaml:"dash,omitempty"`    // distance the champion dashes towards the enemy when casting the spell
+	Targets    int                  `yaml:"targets,omitempty"` // max number of enemies hit by an area of effect spell, if missing only one enemy is hit
 }
 
-func (f *FightTactics) ReadChampion(filePath string) (champion Champion, err error) {
-	yamlFile, err := os.ReadFile(filePath)
-	if err != nil {
-		return Champion{}, err
-	}
-
-	err = yaml.Unmarshal(yamlFile, &champion)
-	if err != nil {
-		return Champion{}, fmt.Errorf("error unmarshalling: %w", err)
-	}
-
-	return champion, nil
-}
-
-func (f *FightTactics) WriteChampion(champion Champion, filePath string) error {
-	data, err := yaml.Marshal(&champion)
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filePath, data, 0700)
-	if err != nil {
-		return err
-	}
+// Crowd control types a spell can apply to the enemy
+const (
+	CCStun    = "stun"    // the enemy can neither move nor cast anything
+	CCKnockUp = "knockup" // like stun, but tenacity does not reduce it
+	CCSilence = "silence" // the enemy cannot cast spells, auto attacks are still allowed
+	CCRoot    = "root"    // the enemy cannot move, but it can still cast anything
+)
 
-	return nil
+type CrowdControl struct {
+	Type     string    `yaml:"type"`
+	Duration []float64 `yaml:"duration"` // duration (in seconds) per spell rank
 }
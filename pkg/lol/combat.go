@@ -0,0 +1,472 @@
+package lol
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	combatMaxDuration   = 180.0 // time (in seconds) after which the fight ends in a draw
+	combatMinActionTime = 0.25  // minimum time (in seconds) between two actions, it avoids zero cast spells to be used endlessly at the same instant
+	combatMoveStep      = 0.1   // time (in seconds) a champion walks before reconsidering what to do
+)
+
+// Target selection policies, i.e. which enemy a champion focuses
+const (
+	TargetLowestHp = "lowest-hp" // the enemy with the lowest health points left
+	TargetClosest  = "closest"   // the nearest enemy
+	TargetCarry    = "carry"     // the enemy able to deal the highest damage
+)
+
+type combatant struct {
+	champion   Champion
+	team       int
+	hp         float64
+	position   float64            // position along the line all champions are standing on
+	nextAction float64            // time at which the champion is free to act again
+	readyAt    map[string]float64 // time at which each spell is off cooldown
+	ccs        []ccInterval       // crowd control applied to the champion
+	deathTime  float64            // time at which the champion died, +Inf while it is alive
+
+	actions     []combatAction
+	damageDealt float64
+	ccApplied   float64 // total crowd control (in seconds) applied to the enemies, tenacity included
+	timeMoving  float64 // time (in seconds) spent walking to close the gap with the enemies
+}
+
+type combatAction struct {
+	time     float64
+	spell    Spell
+	target   *combatant
+	distance float64 // distance from the target when the spell landed
+	targetHp float64 // target health points after the spell landed
+}
+
+type ccInterval struct {
+	ccType   string
+	from, to float64
+}
+
+// combatLanding Spell that has been cast and is going to hit its target once its cast time is over
+type combatLanding struct {
+	time     float64
+	attacker *combatant
+	target   *combatant
+	spell    Spell
+}
+
+// combat Two teams fighting each other on the same timeline: each champion greedily casts its best available spell against the
+// enemy selected by its team targeting policy (walking or dashing towards it when out of range), and crowd control prevents the
+// disabled champion from acting
+type combat struct {
+	fighters  []*combatant
+	targeting [2]string
+	landings  []combatLanding
+	t         float64
+}
+
+func newCombat(team1, team2 []Champion, distance float64, targeting [2]string) *combat {
+	c := &combat{targeting: targeting}
+	for team, champions := range [2][]Champion{team1, team2} {
+		for _, champion := range champions {
+			c.fighters = append(c.fighters, &combatant{
+				champion:  champion,
+				team:      team,
+				hp:        champion.Stats.HealthPoints,
+				position:  float64(team) * math.Max(distance, 0),
+				readyAt:   map[string]float64{},
+				deathTime: math.Inf(1),
+			})
+		}
+	}
+	return c
+}
+
+// run Simulate the fight until a team is wiped out (or the time is over)
+func (c *combat) run() {
+	for {
+		landingPos := c.nextLanding()
+		if !c.isTeamAlive(0) || !c.isTeamAlive(1) {
+			// Spells landing at the same instant of the killing blow still hit
+			if landingPos == -1 || c.landings[landingPos].time > c.t {
+				break
+			}
+		}
+
+		actor := c.nextActor()
+
+		// Actions starting at the same instant happen simultaneously, hence before any spell lands
+		if landingPos != -1 && (actor == nil || c.landings[landingPos].time < actor.nextAction) {
+			landing := c.landings[landingPos]
+			c.landings = append(c.landings[:landingPos], c.landings[landingPos+1:]...)
+			c.t = landing.time
+			if c.t > combatMaxDuration {
+				break
+			}
+			c.applyLanding(landing)
+			continue
+		}
+
+		if actor == nil || actor.nextAction > combatMaxDuration {
+			c.t = combatMaxDuration
+			break
+		}
+		c.t = actor.nextAction
+
+		target := c.pickTarget(actor)
+		if target == nil {
+			actor.nextAction = math.Inf(1)
+			continue
+		}
+		if landing, ok := actor.act(c.t, target); ok {
+			c.landings = append(c.landings, landing)
+		}
+	}
+	c.t = math.Min(c.t, combatMaxDuration)
+}
+
+// winner Winning team (0 or 1), -1 in case of draw
+func (c *combat) winner() int {
+	alive1, alive2 := c.isTeamAlive(0), c.isTeamAlive(1)
+	switch {
+	case alive1 && !alive2:
+		return 0
+	case alive2 && !alive1:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func (c *combat) isTeamAlive(team int) bool {
+	for _, f := range c.fighters {
+		if f.team == team && f.hp > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// nextActor Alive champion acting first, nil if no one can act anymore
+func (c *combat) nextActor() (actor *combatant) {
+	for _, f := range c.fighters {
+		if f.hp > 0 && !math.IsInf(f.nextAction, 1) && (actor == nil || f.nextAction < actor.nextAction) {
+			actor = f
+		}
+	}
+	return actor
+}
+
+// nextLanding Position of the first spell landing in time, -1 if there is none
+func (c *combat) nextLanding() int {
+	pos := -1
+	for i, l := range c.landings {
+		if pos == -1 || l.time < c.landings[pos].time {
+			pos = i
+		}
+	}
+	return pos
+}
+
+// pickTarget Alive enemy to focus according to the team targeting policy
+func (c *combat) pickTarget(attacker *combatant) (target *combatant) {
+	for _, enemy := range c.enemies(attacker) {
+		if target == nil {
+			target = enemy
+			continue
+		}
+		switch c.targeting[attacker.team] {
+		case TargetClosest:
+			if attacker.distanceTo(enemy) < attacker.distanceTo(target) {
+				target = enemy
+			}
+		case TargetCarry:
+			if damagePotential(enemy.champion) > damagePotential(target.champion) {
+				target = enemy
+			}
+		default:
+			if enemy.hp < target.hp {
+				target = enemy
+			}
+		}
+	}
+	return target
+}
+
+func (c *combat) enemies(f *combatant) (enemies []*combatant) {
+	for _, e := range c.fighters {
+		if e.team != f.team && e.hp > 0 {
+			enemies = append(enemies, e)
+		}
+	}
+	return enemies
+}
+
+// applyLanding Move the attacker if the spell is a dash, then apply spell damage and crowd control to the target (plus the
+// other enemies in range, if it is an area of effect spell)
+func (c *combat) applyLanding(landing combatLanding) {
+	attacker := landing.attacker
+	if attacker.deathTime < landing.time {
+		return // the attacker died while casting
+	}
+
+	if landing.spell.Dash > 0 {
+		attacker.moveTowards(landing.target, landing.spell.Dash)
+	}
+	if !isOffensive(landing.spell) {
+		return // pure movement spell
+	}
+
+	var targets []*combatant
+	if landing.target.hp > 0 {
+		targets = append(targets, landing.target)
+	}
+	if landing.spell.Targets > 1 {
+		others := c.enemies(attacker)
+		sort.SliceStable(others, func(i, j int) bool { return attacker.distanceTo(others[i]) < attacker.distanceTo(others[j]) })
+		for _, e := range others {
+			if len(targets) < landing.spell.Targets && e != landing.target && attacker.distanceTo(e) <= attacker.spellRange(landing.spell) {
+				targets = append(targets, e)
+			}
+		}
+	}
+
+	for _, target := range targets {
+		distance := attacker.distanceTo(target)
+		if distance > attacker.spellRange(landing.spell) {
+			continue // the enemy is out of reach
+		}
+
+		damage := spellDamage(landing.spell)
+		target.hp -= damage
+		attacker.damageDealt += damage
+		if target.hp <= 0 && math.IsInf(target.deathTime, 1) {
+			target.deathTime = landing.time
+		}
+
+		for _, cc := range landing.spell.CC {
+			duration := ccDuration(cc, target.champion.Stats.Tenacity)
+			if duration <= 0 {
+				continue
+			}
+			target.ccs = append(target.ccs, ccInterval{ccType: cc.Type, from: landing.time, to: landing.time + duration})
+			attacker.ccApplied += duration
+		}
+
+		attacker.actions = append(attacker.actions, combatAction{time: landing.time, spell: landing.spell, target: target, distance: distance, targetHp: math.Max(target.hp, 0)})
+	}
+}
+
+// act Let the champion cast its best spell at time t against the target, or get closer to it if nothing is in range.
+// It returns false if nothing has been cast.
+func (f *combatant) act(t float64, target *combatant) (combatLanding, bool) {
+	if until := f.ccUntil(t, CCStun, CCKnockUp); until > t {
+		f.nextAction = until
+		return combatLanding{}, false
+	}
+
+	spell, ok := f.pickSpell(t, target)
+	if !ok {
+		if moving := f.walkTowards(t, target); moving > 0 {
+			f.nextAction = t + moving
+			f.timeMoving += moving
+			return combatLanding{}, false
+		}
+		f.nextAction = f.nextReadyTime(t)
+		if math.IsInf(f.nextAction, 1) && hasAnyEffect(f.champion) {
+			f.nextAction = t + combatMoveStep // everything is ready but out of reach, wait for the enemy to come closer
+		}
+		if until := f.ccUntil(t, CCRoot); until > t {
+			f.nextAction = math.Min(f.nextAction, until) // it might be able to walk again once the root is over
+		}
+		return combatLanding{}, false
+	}
+
+	f.readyAt[spell.ID] = t + spell.Cast + spellCooldown(spell) // cooldown starts after cast
+	f.nextAction = t + math.Max(spell.Cast, combatMinActionTime)
+
+	return combatLanding{time: t + spell.Cast, attacker: f, target: target, spell: spell}, true
+}
+
+// pickSpell Prefer a crowd control spell if the target is not already disabled, otherwise the spell with the highest damage.
+// When the target is out of range of every spell, the longest available dash is used to close the gap.
+func (f *combatant) pickSpell(t float64, target *combatant) (best Spell, found bool) {
+	targetDisabled := target.ccUntil(t, CCStun, CCKnockUp) > t
+	distance := f.distanceTo(target)
+	for _, spell := range f.champion.Spells {
+		if !isOffensive(spell) || !f.canCast(spell, t) || distance > f.spellRange(spell) {
+			continue
+		}
+		if !found {
+			best, found = spell, true
+			continue
+		}
+		bestIsCC, spellIsCC := len(best.CC) > 0 && !targetDisabled, len(spell.CC) > 0 && !targetDisabled
+		if (spellIsCC && !bestIsCC) || (spellIsCC == bestIsCC && spellDamage(spell) > spellDamage(best)) {
+			best = spell
+		}
+	}
+	if found || f.ccUntil(t, CCRoot) > t {
+		return best, found
+	}
+
+	for _, spell := range f.champion.Spells {
+		if spell.Dash > 0 && f.canCast(spell, t) && (!found || spell.Dash > best.Dash) {
+			best, found = spell, true
+		}
+	}
+	return best, found
+}
+
+func (f *combatant) canCast(spell Spell, t float64) bool {
+	if !hasEffect(spell) {
+		return false // TODO: excluding spells with no effect atm, but need to take their passive into account
+	}
+	if f.readyAt[spell.ID] > t {
+		return false
+	}
+	return spell.ID == "aa" || f.ccUntil(t, CCSilence) <= t
+}
+
+// walkTowards Walk towards the target until the first available spell is in range (or for a move step at most), it returns the time spent walking
+func (f *combatant) walkTowards(t float64, target *combatant) float64 {
+	if f.champion.Stats.MoveSpeed <= 0 || f.ccUntil(t, CCRoot) > t {
+		return 0
+	}
+
+	// Get in range of the longest range spell available, or of any spell if all of them are in cooldown
+	targetRange, anyRange := -1.0, -1.0
+	for _, spell := range f.champion.Spells {
+		if !isOffensive(spell) {
+			continue
+		}
+		anyRange = math.Max(anyRange, f.spellRange(spell))
+		if f.canCast(spell, t) {
+			targetRange = math.Max(targetRange, f.spellRange(spell))
+		}
+	}
+	if targetRange < 0 {
+		targetRange = anyRange
+	}
+
+	gap := f.distanceTo(target) - targetRange
+	if targetRange < 0 || gap <= 0 {
+		return 0 // nothing to cast, or already in range
+	}
+
+	walked := math.Min(gap, f.champion.Stats.MoveSpeed*combatMoveStep)
+	f.moveTowards(target, walked)
+
+	return walked / f.champion.Stats.MoveSpeed
+}
+
+func (f *combatant) moveTowards(target *combatant, distance float64) {
+	distance = math.Min(distance, f.distanceTo(target))
+	if target.position < f.position {
+		distance = -distance
+	}
+	f.position += distance
+}
+
+func (f *combatant) distanceTo(target *combatant) float64 {
+	return math.Abs(target.position - f.position)
+}
+
+// spellRange Spell range at max rank, auto attacks fall back to the champion attack range.
+// Spells with no range at all can always reach the enemy.
+func (f *combatant) spellRange(spell Spell) float64 {
+	switch {
+	case len(spell.Range) > 0:
+		return spell.Range[len(spell.Range)-1]
+	case spell.ID == "aa" && f.champion.Stats.AttackRange > 0:
+		return f.champion.Stats.AttackRange
+	default:
+		return math.Inf(1)
+	}
+}
+
+// nextReadyTime Time at which the first spell comes off cooldown, +Inf if the champion has nothing to cast
+func (f *combatant) nextReadyTime(t float64) float64 {
+	next := math.Inf(1)
+	for _, spell := range f.champion.Spells {
+		if !hasEffect(spell) {
+			continue
+		}
+		ready := f.readyAt[spell.ID]
+		if spell.ID != "aa" {
+			ready = math.Max(ready, f.ccUntil(t, CCSilence))
+		}
+		if ready > t && ready < next {
+			next = ready
+		}
+	}
+	return next
+}
+
+// ccUntil Time until which the champion is affected by any of the given crowd control types, t if it is not affected at all
+func (f *combatant) ccUntil(t float64, ccTypes ...string) float64 {
+	until := t
+	for _, cc := range f.ccs {
+		if cc.from > t || cc.to <= t {
+			continue
+		}
+		for _, ccType := range ccTypes {
+			if cc.ccType == ccType {
+				until = math.Max(until, cc.to)
+			}
+		}
+	}
+	return until
+}
+
+// ccDuration Crowd control duration at spell max rank, reduced by tenacity (knock-ups ignore it)
+func ccDuration(cc CrowdControl, tenacity float64) float64 {
+	if len(cc.Duration) == 0 {
+		return 0
+	}
+	duration := cc.Duration[len(cc.Duration)-1]
+	if cc.Type == CCKnockUp {
+		return duration
+	}
+	return duration * (1 - math.Min(math.Max(tenacity, 0), 1))
+}
+
+// damagePotential Total damage the champion deals by casting each of its spells once
+func damagePotential(champion Champion) (damage float64) {
+	for _, spell := range champion.Spells {
+		damage += spellDamage(spell)
+	}
+	return damage
+}
+
+func hasAnyEffect(champion Champion) bool {
+	for _, spell := range champion.Spells {
+		if hasEffect(spell) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEffect(spell Spell) bool {
+	return isOffensive(spell) || spell.Dash > 0
+}
+
+// isOffensive True if the spell either deals damage or applies crowd control
+func isOffensive(spell Spell) bool {
+	return spellDamage(spell) > 0 || len(spell.CC) > 0
+}
+
+func spellDamage(spell Spell) float64 {
+	if spell.MaxRank < 1 || spell.MaxRank > len(spell.Damage) {
+		return 0
+	}
+	return spell.Damage[spell.MaxRank-1]
+}
+
+func spellCooldown(spell Spell) float64 {
+	if spell.MaxRank < 1 || spell.MaxRank > len(spell.Cooldown) {
+		return 0
+	}
+	return spell.Cooldown[spell.MaxRank-1]
+}
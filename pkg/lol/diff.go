@@ -0,0 +1,94 @@
+package lol
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldDiff Field whose value changed between two versions of a champion, named after its YAML key (e.g. stats.health_points or spells[Q].damage)
+type FieldDiff struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"` // nil if the field was added
+	New   interface{} `json:"new"` // nil if the field was removed
+}
+
+// diffIgnoredFields Fields expected to differ between two versions of a champion (i.e. where and in which languages it was downloaded from), and thus not a change
+var diffIgnoredFields = map[string]bool{"patch": true, "locale": true, "locales": true}
+
+// DiffChampions Field-level differences between two versions of the same champion (e.g. from two patches)
+func DiffChampions(old, new Champion) []FieldDiff {
+	return diffValues("", reflect.ValueOf(old), reflect.ValueOf(new), nil)
+}
+
+func diffValues(field string, old, new reflect.Value, diffs []FieldDiff) []FieldDiff {
+	switch {
+	case old.Kind() == reflect.Struct:
+		for i := 0; i < old.NumField(); i++ {
+			name := yamlFieldName(old.Type().Field(i))
+			if field != "" {
+				name = field + "." + name
+			}
+			if diffIgnoredFields[name] {
+				continue
+			}
+			diffs = diffValues(name, old.Field(i), new.Field(i), diffs)
+		}
+	case old.Kind() == reflect.Slice && old.Type().Elem().Kind() == reflect.Struct:
+		diffs = diffKeyedSlices(field, old, new, diffs)
+	case old.Kind() == reflect.Slice && old.Len() == 0 && new.Len() == 0:
+		// nil and empty slices are the same as far as champions data is concerned
+	default:
+		if !reflect.DeepEqual(old.Interface(), new.Interface()) {
+			diffs = append(diffs, FieldDiff{Field: field, Old: old.Interface(), New: new.Interface()})
+		}
+	}
+	return diffs
+}
+
+// diffKeyedSlices Match elements by key (e.g. spell id, crowd control type) rather than by position, so that reordering them is not a change
+func diffKeyedSlices(field string, old, new reflect.Value, diffs []FieldDiff) []FieldDiff {
+	newByKey := map[string]reflect.Value{}
+	for i := 0; i < new.Len(); i++ {
+		newByKey[sliceElemKey(new.Index(i), i)] = new.Index(i)
+	}
+
+	oldKeys := map[string]bool{}
+	for i := 0; i < old.Len(); i++ {
+		key := sliceElemKey(old.Index(i), i)
+		oldKeys[key] = true
+		elemField := fmt.Sprintf("%s[%s]", field, key)
+
+		if newElem, ok := newByKey[key]; ok {
+			diffs = diffValues(elemField, old.Index(i), newElem, diffs)
+		} else {
+			diffs = append(diffs, FieldDiff{Field: elemField, Old: old.Index(i).Interface()})
+		}
+	}
+
+	for i := 0; i < new.Len(); i++ {
+		if key := sliceElemKey(new.Index(i), i); !oldKeys[key] {
+			diffs = append(diffs, FieldDiff{Field: fmt.Sprintf("%s[%s]", field, key), New: new.Index(i).Interface()})
+		}
+	}
+
+	return diffs
+}
+
+// sliceElemKey Id (or type) of a slice element, falling back on its position
+func sliceElemKey(v reflect.Value, i int) string {
+	for _, name := range []string{"ID", "Type"} {
+		if f := v.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+	return strconv.Itoa(i)
+}
+
+func yamlFieldName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("yaml"), ",")[0]; name != "" {
+		return name
+	}
+	return strings.ToLower(f.Name)
+}
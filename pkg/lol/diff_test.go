@@ -0,0 +1,48 @@
+package lol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffChampions(t *testing.T) {
+	old := Champion{
+		ID:    "Ahri",
+		Name:  "Ahri",
+		Patch: "14.19.1",
+		Stats: Stats{HealthPoints: 590, AttackDamage: 53},
+		Spells: []Spell{
+			{ID: "aa", MaxRank: 1, Damage: []float64{53}, Cooldown: []float64{0}},
+			{ID: "AhriQ", MaxRank: 5, Damage: []float64{40, 65, 90, 115, 140}, Cooldown: []float64{7}},
+			{ID: "AhriE", MaxRank: 5, Damage: []float64{80}, CC: []CrowdControl{{Type: CCStun, Duration: []float64{1.4}}}},
+		},
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		new := old
+		new.Spells = append([]Spell{}, old.Spells[2], old.Spells[0], old.Spells[1]) // reordered only
+
+		assert.Empty(t, DiffChampions(old, new))
+	})
+
+	t.Run("field-level changes", func(t *testing.T) {
+		new := old
+		new.Patch = "14.20.1"
+		new.Stats.HealthPoints = 570
+		new.Spells = []Spell{
+			old.Spells[0],
+			{ID: "AhriQ", MaxRank: 5, Damage: []float64{40, 65, 90, 115, 150}, Cooldown: []float64{7}},
+			{ID: "AhriR", MaxRank: 3, Damage: []float64{60}},
+		}
+
+		diffs := DiffChampions(old, new)
+
+		assert.Equal(t, []FieldDiff{
+			{Field: "stats.health_points", Old: 590.0, New: 570.0},
+			{Field: "spells[AhriQ].damage", Old: []float64{40, 65, 90, 115, 140}, New: []float64{40, 65, 90, 115, 150}},
+			{Field: "spells[AhriE]", Old: old.Spells[2]},
+			{Field: "spells[AhriR]", New: new.Spells[2]},
+		}, diffs)
+	})
+}
@@ -0,0 +1,60 @@
+package lol
+
+import (
+	"math"
+)
+
+// DuelOptions Starting conditions of a duel
+type DuelOptions struct {
+	Distance float64 // initial distance between the two champions, zero means both are already in range of every spell
+}
+
+// DuelSol Outcome of a two-sided fight, where both champions attack each other at the same time
+type DuelSol struct {
+	Winner   string  // name of the winning champion, empty in case of draw
+	Duration float64 // time (in seconds) at which the duel ended
+	Sides    [2]DuelSide
+}
+
+type DuelSide struct {
+	Champion     string
+	Actions      []DuelAction
+	DamageDealt  float64
+	CCApplied    float64 // total crowd control (in seconds) applied to the enemy, tenacity included
+	TimeMoving   float64 // time (in seconds) spent walking to close the gap with the enemy
+	HealthPoints float64 // health points left at the end of the duel
+}
+
+type DuelAction struct {
+	Time     float64 // time (in seconds) at which the spell landed
+	Spell    Spell
+	Distance float64 // distance between the champions when the spell landed
+	EnemyHp  float64 // enemy health points after the spell landed
+}
+
+// Duel Champion1 vs Champion2 on the same timeline: each champion greedily casts its best available spell (walking or dashing towards the enemy when out of range), and crowd control prevents the disabled champion from acting
+func (f *FightTactics) Duel(champion1, champion2 Champion, opts DuelOptions) DuelSol {
+	fight := newCombat([]Champion{champion1}, []Champion{champion2}, opts.Distance, [2]string{})
+	fight.run()
+
+	sol := DuelSol{Duration: fight.t}
+	for i, c := range fight.fighters {
+		sol.Sides[i] = DuelSide{
+			Champion:     c.champion.Name,
+			DamageDealt:  c.damageDealt,
+			CCApplied:    c.ccApplied,
+			TimeMoving:   c.timeMoving,
+			HealthPoints: math.Max(c.hp, 0),
+		}
+		for _, a := range c.actions {
+			sol.Sides[i].Actions = append(sol.Sides[i].Actions, DuelAction{Time: a.time, Spell: a.spell, Distance: a.distance, EnemyHp: a.targetHp})
+		}
+	}
+	if winner := fight.winner(); winner != -1 {
+		sol.Winner = fight.fighters[winner].champion.Name
+	}
+
+	f.log.Printf("[%s vs %s] Duel ended in %.2fs (winner: %s)\n", champion1.Name, champion2.Name, sol.Duration, sol.Winner)
+
+	return sol
+}
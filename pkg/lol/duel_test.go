@@ -0,0 +1,146 @@
+package lol
+
+import (
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuel(t *testing.T) {
+	fightTactics := FightTactics{&loggertest.Logger{}}
+
+	aa := Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}, Cast: 0}
+
+	t.Run("faster champion wins", func(t *testing.T) {
+		champion1 := getMockChampion(withName("c1"), withHealthPoints(100), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{50}, Cooldown: []float64{1}}))
+		champion2 := getMockChampion(withName("c2"), withHealthPoints(100), withSpells(aa))
+
+		duel := fightTactics.Duel(champion1, champion2, DuelOptions{})
+
+		assert.Equal(t, "c1", duel.Winner)
+		assert.Equal(t, 1.0, duel.Duration) // second auto attack
+		assert.Equal(t, 2, len(duel.Sides[0].Actions))
+		assert.Equal(t, 100.0, duel.Sides[0].DamageDealt)
+		assert.Equal(t, 80.0, duel.Sides[0].HealthPoints)
+	})
+
+	t.Run("stun prevents enemy from acting", func(t *testing.T) {
+		stun := Spell{ID: "q", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{10}, CC: []CrowdControl{{Type: CCStun, Duration: []float64{1.5}}}}
+		champion1 := getMockChampion(withName("c1"), withHealthPoints(100), withSpells(aa, stun))
+		champion2 := getMockChampion(withName("c2"), withHealthPoints(100), withSpells(aa))
+
+		duel := fightTactics.Duel(champion1, champion2, DuelOptions{})
+
+		assert.Equal(t, "c1", duel.Winner)
+		assert.Equal(t, 1.5, duel.Sides[0].CCApplied)
+		assert.Equal(t, 0.0, duel.Sides[1].CCApplied)
+		assert.Equal(t, "q", duel.Sides[0].Actions[0].Spell.ID)
+		assert.Equal(t, 0.0, duel.Sides[1].Actions[0].Time)
+		assert.Equal(t, 1.5, duel.Sides[1].Actions[1].Time) // first action once the stun is over
+	})
+
+	t.Run("tenacity reduces stun but not knock-up", func(t *testing.T) {
+		stun := Spell{ID: "q", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{100}, CC: []CrowdControl{{Type: CCStun, Duration: []float64{2}}}}
+		knockUp := Spell{ID: "r", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{100}, CC: []CrowdControl{{Type: CCKnockUp, Duration: []float64{1}}}}
+		champion1 := getMockChampion(withName("c1"), withHealthPoints(100), withSpells(aa, stun, knockUp))
+		champion2 := getMockChampion(withName("c2"), withHealthPoints(100), withSpells(aa))
+		champion2.Stats.Tenacity = 0.5
+
+		duel := fightTactics.Duel(champion1, champion2, DuelOptions{})
+
+		assert.Equal(t, 2.0, duel.Sides[0].CCApplied) // 2s * 50% + 1s
+	})
+
+	t.Run("silence allows auto attacks only", func(t *testing.T) {
+		silence := Spell{ID: "q", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{100}, CC: []CrowdControl{{Type: CCSilence, Duration: []float64{5}}}}
+		nuke := Spell{ID: "w", MaxRank: 1, Damage: []float64{100}, Cooldown: []float64{2}}
+		champion1 := getMockChampion(withName("c1"), withHealthPoints(1000), withSpells(silence))
+		champion2 := getMockChampion(withName("c2"), withHealthPoints(1000), withSpells(aa, nuke))
+
+		duel := fightTactics.Duel(champion1, champion2, DuelOptions{})
+
+		var nukeTimes []float64
+		for _, a := range duel.Sides[1].Actions {
+			if a.Spell.ID == "w" {
+				nukeTimes = append(nukeTimes, a.Time)
+			}
+		}
+		assert.Equal(t, []float64{0, 5}, nukeTimes[:2]) // cast before the silence landed, then once it is over
+	})
+
+	t.Run("draw", func(t *testing.T) {
+		champion1 := getMockChampion(withName("c1"), withHealthPoints(100), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{1}}))
+		champion2 := getMockChampion(withName("c2"), withHealthPoints(100), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{1}}))
+
+		duel := fightTactics.Duel(champion1, champion2, DuelOptions{})
+
+		assert.Equal(t, "", duel.Winner)
+		assert.Equal(t, combatMaxDuration, duel.Duration)
+	})
+}
+
+func TestDuelWithDistance(t *testing.T) {
+	fightTactics := FightTactics{&loggertest.Logger{}}
+
+	melee := getMockChampion(withName("melee"), withHealthPoints(100), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}}))
+	melee.Stats.AttackRange = 100
+	melee.Stats.MoveSpeed = 200
+
+	ranged := getMockChampion(withName("ranged"), withHealthPoints(100), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}}))
+	ranged.Stats.AttackRange = 500
+
+	t.Run("melee walks to close the gap", func(t *testing.T) {
+		duel := fightTactics.Duel(melee, ranged, DuelOptions{Distance: 500})
+
+		assert.Equal(t, "ranged", duel.Winner)
+		assert.InDelta(t, 2.0, duel.Sides[0].TimeMoving, 1e-9) // 400 units at 200 speed
+		assert.InDelta(t, 2.0, duel.Sides[0].Actions[0].Time, 1e-9)
+		assert.InDelta(t, 100.0, duel.Sides[0].Actions[0].Distance, 1e-9)
+		assert.Equal(t, 0.0, duel.Sides[1].Actions[0].Time)
+	})
+
+	t.Run("dash closes the gap", func(t *testing.T) {
+		dasher := melee
+		dasher.Spells = append([]Spell{}, melee.Spells...)
+		dasher.Spells = append(dasher.Spells, Spell{ID: "e", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{100}, Dash: 400})
+
+		duel := fightTactics.Duel(dasher, ranged, DuelOptions{Distance: 500})
+
+		assert.Equal(t, 0.0, duel.Sides[0].TimeMoving)
+		assert.InDelta(t, combatMinActionTime, duel.Sides[0].Actions[0].Time, 1e-9)
+		assert.InDelta(t, 100.0, duel.Sides[0].Actions[0].Distance, 1e-9)
+	})
+
+	t.Run("root prevents walking", func(t *testing.T) {
+		root := Spell{ID: "q", MaxRank: 1, Damage: []float64{0}, Cooldown: []float64{100}, Range: []float64{600}, CC: []CrowdControl{{Type: CCRoot, Duration: []float64{2}}}}
+		rooter := ranged
+		rooter.Spells = append([]Spell{}, ranged.Spells...)
+		rooter.Spells = append(rooter.Spells, root)
+
+		duel := fightTactics.Duel(melee, rooter, DuelOptions{Distance: 500})
+
+		assert.InDelta(t, 3.9, duel.Sides[0].Actions[0].Time, 1e-9) // 0.1s walking before the root landed, rooted for 2s, then 1.9s walking
+	})
+
+	t.Run("no move speed", func(t *testing.T) {
+		duel := fightTactics.Duel(ranged, ranged, DuelOptions{Distance: 1000})
+
+		assert.Equal(t, "", duel.Winner)
+		assert.Equal(t, 0, len(duel.Sides[0].Actions))
+	})
+}
+
+func TestCCDuration(t *testing.T) {
+	t.Run("stun with tenacity", func(t *testing.T) {
+		assert.Equal(t, 0.75, ccDuration(CrowdControl{Type: CCStun, Duration: []float64{1, 1.5}}, 0.5))
+	})
+
+	t.Run("knock-up ignores tenacity", func(t *testing.T) {
+		assert.Equal(t, 1.0, ccDuration(CrowdControl{Type: CCKnockUp, Duration: []float64{1}}, 0.5))
+	})
+
+	t.Run("no duration", func(t *testing.T) {
+		assert.Equal(t, 0.0, ccDuration(CrowdControl{Type: CCRoot}, 0))
+	})
+}
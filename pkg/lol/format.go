@@ -0,0 +1,150 @@
+package lol
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Champion file formats, named after their file extension
+const (
+	FormatYAML = "yml"
+	FormatJSON = "json"
+	FormatTOML = "toml"
+)
+
+// ChampionFileConverter Repository able to rewrite its champion files in another format
+type ChampionFileConverter interface {
+	ConvertChampion(name, format string) error
+}
+
+// championFileFormats Formats champion files are looked up in, in order of preference when a champion is stored in several of them
+var championFileFormats = []string{FormatYAML, FormatJSON, FormatTOML}
+
+// FormatFromPath Champion file format of the given file, after its extension (.yaml being the same as .yml)
+func FormatFromPath(file string) (string, error) {
+	format := strings.TrimPrefix(strings.ToLower(path.Ext(file)), ".")
+	if format == "yaml" {
+		format = FormatYAML
+	}
+	if !isChampionFileFormat(format) {
+		return "", fmt.Errorf("unknown format of %s, expected a .%s, .%s or .%s file", file, FormatYAML, FormatJSON, FormatTOML)
+	}
+	return format, nil
+}
+
+func isChampionFileFormat(format string) bool {
+	for _, f := range championFileFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalChampion Champion file content in the given format. Whatever the format, fields are named after their YAML keys (e.g. health_points)
+func MarshalChampion(champion Champion, format string) ([]byte, error) {
+	data, err := yaml.Marshal(&champion)
+	if err != nil || format == FormatYAML {
+		return data, err
+	}
+
+	var values interface{}
+	err = yaml.Unmarshal(data, &values)
+	if err != nil {
+		return nil, err
+	}
+	values = stringKeys(values)
+
+	switch format {
+	case FormatJSON:
+		data, err = json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatTOML:
+		var buf bytes.Buffer
+		err = toml.NewEncoder(&buf).Encode(values)
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown champion file format %q", format)
+	}
+}
+
+// championFileToYAML Champion file content of the given format converted to YAML, so that every format goes through the same
+// migration, strict decoding and validation
+func championFileToYAML(data []byte, format string) ([]byte, error) {
+	var values interface{}
+	switch format {
+	case FormatYAML:
+		return data, nil
+	case FormatJSON:
+		err := json.Unmarshal(data, &values)
+		if err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		var table map[string]interface{}
+		err := toml.Unmarshal(data, &table)
+		if err != nil {
+			return nil, err
+		}
+		values = table
+	default:
+		return nil, fmt.Errorf("unknown champion file format %q", format)
+	}
+	return yaml.Marshal(values)
+}
+
+// readChampionFile Raw content of the champion file in dir, whatever its format, along with its file name and format
+func readChampionFile(fsys fs.FS, dir, name string) ([]byte, string, string, error) {
+	for _, format := range championFileFormats {
+		file := championFileName(name, format)
+		data, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return data, file, format, err
+	}
+	return nil, "", "", fs.ErrNotExist
+}
+
+// championFileFormatIn Format of the champion file in dir, empty if there is none
+func championFileFormatIn(fsys fs.FS, dir, name string) string {
+	for _, format := range championFileFormats {
+		if _, err := fs.Stat(fsys, path.Join(dir, championFileName(name, format))); err == nil {
+			return format
+		}
+	}
+	return ""
+}
+
+// stringKeys Decoded YAML value with string map keys, as JSON and TOML require
+func stringKeys(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			m[fmt.Sprint(k)] = stringKeys(v)
+		}
+		return m
+	case []interface{}:
+		for i, v := range value {
+			value[i] = stringKeys(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
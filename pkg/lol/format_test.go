@@ -0,0 +1,121 @@
+package lol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	for file, format := range map[string]string{"jhin.yml": FormatYAML, "Jhin.YAML": FormatYAML, "dir/jhin.json": FormatJSON, "jhin.toml": FormatTOML} {
+		f, err := FormatFromPath(file)
+
+		assert.Nil(t, err)
+		assert.Equal(t, format, f, file)
+	}
+
+	_, err := FormatFromPath("jhin.xml")
+
+	assert.NotNil(t, err)
+}
+
+func TestMarshalChampion(t *testing.T) {
+	for _, format := range []string{FormatYAML, FormatJSON, FormatTOML} {
+		t.Run(format, func(t *testing.T) {
+			data, err := MarshalChampion(getMockChampion(), format)
+			assert.Nil(t, err)
+			assert.Contains(t, string(data), "health_points") // YAML keys whatever the format
+
+			yamlData, err := championFileToYAML(data, format)
+			assert.Nil(t, err)
+			champion, issues := decodeChampion(yamlData, true)
+			assert.Empty(t, issues)
+			assert.Equal(t, getMockChampion(), champion)
+		})
+	}
+
+	_, err := MarshalChampion(getMockChampion(), "xml")
+
+	assert.NotNil(t, err)
+}
+
+func TestDirRepositoryFormats(t *testing.T) {
+	t.Run("convert and read", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := NewDirRepository(dir)
+		err := repo.WriteChampion(getMockChampion())
+		assert.Nil(t, err)
+
+		for _, format := range []string{FormatJSON, FormatTOML, FormatYAML} {
+			err = repo.(ChampionFileConverter).ConvertChampion("twistedfate", format)
+			assert.Nil(t, err)
+
+			entries, err := os.ReadDir(dir)
+			assert.Nil(t, err)
+			assert.Len(t, entries, 1)
+			assert.Equal(t, "twistedfate."+format, entries[0].Name())
+
+			champion, err := repo.ReadChampion("Twisted Fate")
+			assert.Nil(t, err)
+			assert.Equal(t, getMockChampion(), champion)
+		}
+	})
+
+	t.Run("write keeps the format", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := NewDirRepository(dir)
+		data, err := MarshalChampion(getMockChampion(), FormatJSON)
+		assert.Nil(t, err)
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "twistedfate.json"), data, 0600))
+
+		champion := getMockChampion()
+		champion.Stats.HealthPoints = 600
+		err = repo.WriteChampion(champion)
+		assert.Nil(t, err)
+
+		_, err = os.Stat(filepath.Join(dir, "twistedfate.yml"))
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+		read, err := repo.ReadChampion("twistedfate")
+		assert.Nil(t, err)
+		assert.Equal(t, 600.0, read.Stats.HealthPoints)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "jhin.json"), []byte(`{"id": "Jhin",`), 0600))
+
+		_, err := NewDirRepository(dir).ReadChampion("jhin")
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, filepath.Join(dir, "jhin.json"), validationErr.File)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		repo := NewDirRepository(t.TempDir())
+		assert.Nil(t, repo.WriteChampion(getMockChampion()))
+
+		err := repo.(ChampionFileConverter).ConvertChampion("twistedfate", "xml")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestListChampionFilesFormats(t *testing.T) {
+	fsys := fstest.MapFS{
+		"lol/ahri.json":        {Data: []byte("{}")},
+		"lol/jhin.toml":        {Data: []byte("")},
+		"lol/twistedfate.yml":  {Data: []byte("")},
+		"lol/twistedfate.json": {Data: []byte("{}")},
+		"lol/notes.txt":        {Data: []byte("")},
+	}
+
+	names, err := listChampionFiles(fsys, "lol")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ahri", "jhin", "twistedfate"}, names)
+}
@@ -0,0 +1,89 @@
+package lol
+
+// DefaultLocale Locale of the champions data not stating any
+const DefaultLocale = "en_US"
+
+// Localization Display names of a champion in a given locale, numeric data being the same in every locale
+type Localization struct {
+	Name    string            `yaml:"name"`
+	Title   string            `yaml:"title"`
+	Passive Passive           `yaml:"passive"`
+	Spells  map[string]string `yaml:"spells,omitempty"` // spell name by spell id
+}
+
+// Localization Display names of the champion in its own locale
+func (c Champion) Localization() Localization {
+	l := Localization{Name: c.Name, Title: c.Title, Passive: c.Passive, Spells: map[string]string{}}
+	for _, s := range c.Spells {
+		if s.Name != "" {
+			l.Spells[s.ID] = s.Name
+		}
+	}
+	return l
+}
+
+// Localize Champion with its display names in the given locale, unchanged if there are no names for it
+func (c Champion) Localize(locale string) Champion {
+	if locale == "" || locale == c.locale() {
+		return c
+	}
+	l, ok := c.Locales[locale]
+	if !ok {
+		return c
+	}
+	localized := c.withLocalization(l)
+	localized.Locale = locale
+	return localized
+}
+
+// MergeLocales Champion data just downloaded (or imported), keeping the display names of every other locale the
+// stored champion has. If the downloaded locale is a different one, the stored names stay the main ones while the
+// downloaded ones are added to the other locales.
+func MergeLocales(stored, downloaded Champion) Champion {
+	locales := map[string]Localization{}
+	for locale, l := range stored.Locales {
+		locales[locale] = l
+	}
+
+	merged := downloaded
+	if stored.locale() != downloaded.locale() {
+		locales[downloaded.locale()] = downloaded.Localization()
+		merged = downloaded.withLocalization(stored.Localization())
+		merged.Locale = stored.Locale
+	}
+	delete(locales, merged.locale())
+
+	merged.Locales = nil
+	if len(locales) > 0 {
+		merged.Locales = locales
+	}
+	return merged
+}
+
+func (c Champion) locale() string {
+	if c.Locale == "" {
+		return DefaultLocale
+	}
+	return c.Locale
+}
+
+// withLocalization Champion with the given display names, falling back on its own ones for anything missing
+func (c Champion) withLocalization(l Localization) Champion {
+	if l.Name != "" {
+		c.Name = l.Name
+	}
+	if l.Title != "" {
+		c.Title = l.Title
+	}
+	if l.Passive != (Passive{}) {
+		c.Passive = l.Passive
+	}
+
+	c.Spells = append([]Spell{}, c.Spells...)
+	for i, s := range c.Spells {
+		if name, ok := l.Spells[s.ID]; ok {
+			c.Spells[i].Name = name
+		}
+	}
+	return c
+}
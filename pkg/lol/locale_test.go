@@ -0,0 +1,76 @@
+package lol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalize(t *testing.T) {
+	champion := getMockChampion(withLocale("", "the Card Master"), withLocales(map[string]Localization{
+		"it_IT": {Name: "Twisted Fate", Title: "il Maestro delle Carte", Spells: map[string]string{"WildCards": "Carte Selvagge"}},
+	}))
+
+	t.Run("other locale", func(t *testing.T) {
+		localized := champion.Localize("it_IT")
+
+		assert.Equal(t, "it_IT", localized.Locale)
+		assert.Equal(t, "il Maestro delle Carte", localized.Title)
+		assert.Equal(t, "Loaded Dice", localized.Passive.Name) // missing, fall back on the main one
+		assert.Equal(t, "Auto Attack", localized.Spells[0].Name)
+		assert.Equal(t, "Carte Selvagge", localized.Spells[1].Name)
+		assert.Equal(t, "Wild Cards", champion.Spells[1].Name)
+	})
+
+	t.Run("unknown locale", func(t *testing.T) {
+		assert.Equal(t, champion, champion.Localize("ko_KR"))
+	})
+
+	t.Run("own locale", func(t *testing.T) {
+		assert.Equal(t, champion, champion.Localize(DefaultLocale))
+	})
+}
+
+func TestMergeLocales(t *testing.T) {
+	english := getMockChampion(withLocale("en_US", "the Card Master"), withLocales(nil))
+	italian := getMockChampion(withLocale("it_IT", "il Maestro delle Carte"), withLocales(nil))
+	italian.Spells[1].Name = "Carte Selvagge"
+
+	t.Run("new locale", func(t *testing.T) {
+		italian := italian
+		italian.Stats.HealthPoints = 600
+
+		merged := MergeLocales(english, italian)
+
+		assert.Equal(t, "en_US", merged.Locale)
+		assert.Equal(t, "the Card Master", merged.Title)
+		assert.Equal(t, "Wild Cards", merged.Spells[1].Name)
+		assert.Equal(t, 600.0, merged.Stats.HealthPoints)
+		assert.Equal(t, italian.Localization(), merged.Locales["it_IT"])
+	})
+
+	t.Run("same locale", func(t *testing.T) {
+		stored := MergeLocales(english, italian)
+
+		merged := MergeLocales(stored, english)
+
+		assert.Equal(t, "the Card Master", merged.Title)
+		assert.Equal(t, italian.Localization(), merged.Locales["it_IT"])
+	})
+
+	t.Run("stored locale downloaded again", func(t *testing.T) {
+		stored := MergeLocales(english, italian)
+
+		merged := MergeLocales(stored, italian)
+
+		assert.Equal(t, "en_US", merged.Locale)
+		assert.Equal(t, "the Card Master", merged.Title)
+		assert.Len(t, merged.Locales, 1)
+	})
+
+	t.Run("no locales", func(t *testing.T) {
+		merged := MergeLocales(getMockChampion(withLocale("", "the Card Master"), withLocales(nil)), english)
+
+		assert.Nil(t, merged.Locales)
+	})
+}
@@ -0,0 +1,128 @@
+package lol
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	CurrentSchemaVersion     = "2.0.0" // schema version of the champion files written
+	unversionedSchemaVersion = "1.1.0" // schema version of the champion files written before they had any
+)
+
+// championMigration Upgrade of a decoded champion file from a schema version to the next one
+type championMigration struct {
+	from, to string
+	migrate  func(champion map[interface{}]interface{})
+}
+
+// championMigrations Migration chain, each migration upgrading the files of the schema version the previous one ends in
+var championMigrations = []championMigration{
+	{from: "1.0.0", to: unversionedSchemaVersion, migrate: migrateChampionV1},
+	{from: unversionedSchemaVersion, to: "2.0.0", migrate: migrateUnversionedChampion},
+}
+
+// migrateChampionV1 Schema 1.0.0 had no id, no auto attack spell (which is made out of the attack stats, as when downloading) and named
+// health points "hp", along with stats the fights do not use (e.g. armor, per level growth) which are dropped
+func migrateChampionV1(champion map[interface{}]interface{}) {
+	if _, ok := champion["id"]; !ok {
+		if name, ok := champion["name"].(string); ok {
+			champion["id"] = strings.NewReplacer(" ", "", "'", "", ".", "").Replace(name)
+		}
+	}
+
+	stats, ok := champion["stats"].(map[interface{}]interface{})
+	if !ok {
+		return
+	}
+	if hp, ok := stats["hp"]; ok {
+		stats["health_points"] = hp
+	}
+	for _, stat := range []string{"hp", "hp_per_level", "armor", "armor_per_level", "spell_block", "spell_block_per_level", "attack_damage_per_level", "attack_speed_per_level"} {
+		delete(stats, stat)
+	}
+
+	attackDamage, hasDamage := stats["attack_damage"]
+	attackSpeed, hasSpeed := stats["attack_speed"]
+	spells, _ := champion["spells"].([]interface{})
+	if !hasDamage || !hasSpeed || hasAutoAttack(spells) {
+		return
+	}
+	champion["spells"] = append(spells, map[interface{}]interface{}{
+		"id":       AutoAttackID,
+		"name":     "Auto Attack",
+		"max_rank": 1,
+		"damage":   []interface{}{attackDamage},
+		"cooldown": []interface{}{attackSpeed},
+		"cast":     0,
+	})
+}
+
+func hasAutoAttack(spells []interface{}) bool {
+	for _, spell := range spells {
+		if s, ok := spell.(map[interface{}]interface{}); ok && s["id"] == AutoAttackID {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateUnversionedChampion Schema 1.1.0, the one of the files written before versioning, only lacked the version (set once migrated)
+func migrateUnversionedChampion(_ map[interface{}]interface{}) {}
+
+// ChampionFileVersion Schema version of the champion file, unversioned files being of the schema in use before versioning
+func ChampionFileVersion(data []byte) (string, error) {
+	var file struct {
+		Version string `yaml:"version"`
+	}
+	err := yaml.Unmarshal(data, &file)
+	if err != nil {
+		return "", err
+	}
+	if file.Version == "" {
+		return unversionedSchemaVersion, nil
+	}
+	return file.Version, nil
+}
+
+// MigrateChampionFile Champion of the file (in YAML, see ChampionFileReader) migrated to the current schema version and strictly decoded.
+// It is not validated, so that migrating does not depend on the values the migration leaves untouched (e.g. those an override corrects)
+func MigrateChampionFile(name string, data []byte) (Champion, error) {
+	champion, issues := decodeChampion(data, false)
+	if len(issues) > 0 {
+		return Champion{}, &ValidationError{Champion: name, Issues: issues}
+	}
+	return champion, nil
+}
+
+// migrateChampionFile Champion file upgraded to CurrentSchemaVersion by going through the migration chain
+func migrateChampionFile(data []byte) ([]byte, error) {
+	version, err := ChampionFileVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	if version == CurrentSchemaVersion {
+		return data, nil
+	}
+
+	var champion map[interface{}]interface{}
+	err = yaml.Unmarshal(data, &champion)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range championMigrations {
+		if m.from == version {
+			m.migrate(champion)
+			version = m.to
+		}
+	}
+	if version != CurrentSchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version %s, expected %s at most", version, CurrentSchemaVersion)
+	}
+
+	champion["version"] = CurrentSchemaVersion
+	return yaml.Marshal(champion)
+}
@@ -0,0 +1,78 @@
+package lol
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateChampionFile(t *testing.T) {
+	t.Run("from 1.0.0", func(t *testing.T) {
+		data, err := os.ReadFile(fmt.Sprintf(mockChampionPathTmpl, mockChampion3))
+		assert.Nil(t, err)
+
+		version, err := ChampionFileVersion(data)
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.0", version)
+
+		champion, issues := decodeChampion(data, false)
+
+		assert.Empty(t, issues)
+		assert.Equal(t, CurrentSchemaVersion, champion.Version)
+		assert.Equal(t, "TestChampion3", champion.ID)
+		assert.Equal(t, Stats{HealthPoints: 500, AttackDamage: 53, AttackSpeed: 0.668}, champion.Stats)
+		assert.Len(t, champion.Spells, 5)
+		assert.Equal(t, Spell{ID: AutoAttackID, Name: "Auto Attack", MaxRank: 1, Damage: []float64{53}, Cooldown: []float64{0.668}}, champion.Spells[4])
+		assert.Empty(t, ValidateChampion(champion))
+	})
+
+	t.Run("unversioned", func(t *testing.T) {
+		data := []byte("id: Jhin\nname: Jhin\nstats:\n  health_points: 655\n")
+
+		version, err := ChampionFileVersion(data)
+		assert.Nil(t, err)
+		assert.Equal(t, unversionedSchemaVersion, version)
+
+		migrated, err := migrateChampionFile(data)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "id: Jhin\nname: Jhin\nstats:\n  health_points: 655\nversion: 2.0.0\n", string(migrated))
+	})
+
+	t.Run("current", func(t *testing.T) {
+		data := []byte("version: 2.0.0\nid: Jhin\nname: Jhin\nstats:\n  health_points: 655\n")
+
+		migrated, err := migrateChampionFile(data)
+
+		assert.Nil(t, err)
+		assert.Equal(t, data, migrated)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := migrateChampionFile([]byte("version: 9.0.0\nid: Jhin\n"))
+
+		assert.EqualError(t, err, "unsupported schema version 9.0.0, expected 2.0.0 at most")
+	})
+
+	t.Run("read and written by a repository", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := NewDirRepository(dir)
+		err := os.WriteFile(filepath.Join(dir, "jhin.yml"), []byte("version: 1.0.0\nname: Jhin\nstats:\n  hp: 655\n  armor: 24\nspells:\n- {id: aa, max_rank: 1, damage: [59], cooldown: [1.6]}\n"), 0600)
+		assert.Nil(t, err)
+
+		champion, err := repo.ReadChampion("jhin")
+		assert.Nil(t, err)
+		assert.Equal(t, 655.0, champion.Stats.HealthPoints)
+
+		err = repo.WriteChampion(champion)
+		assert.Nil(t, err)
+		data, err := os.ReadFile(filepath.Join(dir, "jhin.yml"))
+		assert.Nil(t, err)
+		version, err := ChampionFileVersion(data)
+		assert.Nil(t, err)
+		assert.Equal(t, CurrentSchemaVersion, version)
+	})
+}
@@ -0,0 +1,194 @@
+package lol
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSuggestions        = 3
+	maxSuggestionDistance = 2 // edits (e.g. a typo, or two swapped letters) a name can be away from a champion to be suggested
+	minSuggestionPrefix   = 3 // length a name must have to be suggested the champions it is the beginning of
+)
+
+// championAliases Common nicknames of the champions, by champion id
+var championAliases = map[string][]string{
+	"AurelionSol":  {"asol"},
+	"Cassiopeia":   {"cass"},
+	"DrMundo":      {"mundo"},
+	"Fiddlesticks": {"fiddle"},
+	"Gangplank":    {"gp"},
+	"Heimerdinger": {"heimer", "donger"},
+	"JarvanIV":     {"j4", "jarvan"},
+	"KogMaw":       {"kog"},
+	"LeeSin":       {"lee"},
+	"MasterYi":     {"yi"},
+	"MissFortune":  {"mf"},
+	"MonkeyKing":   {"wukong"},
+	"Nunu":         {"nunu and willump"},
+	"TahmKench":    {"tahm"},
+	"TwistedFate":  {"tf"},
+	"Warwick":      {"ww"},
+	"XinZhao":      {"xin"},
+}
+
+// UnknownChampionError Name matching no champion, along with the closest champions if any
+type UnknownChampionError struct {
+	Name        string
+	Suggestions []string // display names of the closest champions
+}
+
+func (e *UnknownChampionError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("%v: %s", ErrChampionNotFound, e.Name)
+	}
+	return fmt.Sprintf("%v: %s (did you mean %s?)", ErrChampionNotFound, e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+func (e *UnknownChampionError) Unwrap() error {
+	return ErrChampionNotFound
+}
+
+// ChampionIndex Champions looked up by id (e.g. MonkeyKing), display name (e.g. Wukong) or alias (e.g. mf), whatever the case, spaces and
+// punctuation (e.g. kaisa for Kai'Sa), suggesting the closest champions to an unknown name (e.g. Jhin for jihn)
+type ChampionIndex struct {
+	ids   map[string]string // champion id by normalized id, name or alias
+	names map[string]string // display name by champion id
+}
+
+func NewChampionIndex() *ChampionIndex {
+	return &ChampionIndex{ids: map[string]string{}, names: map[string]string{}}
+}
+
+// NewChampionIndexFromRepository Index of the champions of the repository, along with their aliases. Champions that cannot be read
+// (e.g. invalid ones) are still indexed by the name they are stored by
+func NewChampionIndexFromRepository(championRepo ChampionRepository) (*ChampionIndex, error) {
+	storedNames, err := championRepo.ListChampions()
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewChampionIndex()
+	for _, storedName := range storedNames {
+		champion, err := championRepo.ReadChampion(storedName)
+		if err != nil {
+			index.Add(storedName, storedName)
+			continue
+		}
+		index.Add(championKey(champion), champion.Name)
+	}
+	return index, nil
+}
+
+// Add Index the champion by its id, display name (the id if empty), common nicknames (e.g. mf) and the given aliases
+func (i *ChampionIndex) Add(id, name string, aliases ...string) {
+	if name == "" {
+		name = id
+	}
+	i.names[id] = name
+	keys := append([]string{id, name}, championAliases[id]...)
+	for _, key := range append(keys, aliases...) {
+		if k := indexKey(key); k != "" {
+			i.ids[k] = id
+		}
+	}
+}
+
+// Resolve Id of the champion the name refers to, an *UnknownChampionError with the closest champions if none
+func (i *ChampionIndex) Resolve(name string) (string, error) {
+	if id, ok := i.ids[indexKey(name)]; ok {
+		return id, nil
+	}
+	return "", &UnknownChampionError{Name: name, Suggestions: i.Suggest(name)}
+}
+
+// Suggest Display names of the champions closest to the name: those a few edits away from it, then those it is the beginning of
+func (i *ChampionIndex) Suggest(name string) []string {
+	key := indexKey(name)
+	if key == "" {
+		return nil
+	}
+
+	distances := map[string]int{} // closest distance by champion id
+	for k, id := range i.ids {
+		d := editDistance(key, k)
+		if d > maxSuggestionDistance || d >= len(k) || d >= len(key) {
+			d = -1
+			if len(key) >= minSuggestionPrefix && strings.HasPrefix(k, key) {
+				d = maxSuggestionDistance + 1
+			}
+		}
+		if best, ok := distances[id]; d >= 0 && (!ok || d < best) {
+			distances[id] = d
+		}
+	}
+
+	ids := make([]string, 0, len(distances))
+	for id := range distances {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		if distances[ids[a]] != distances[ids[b]] {
+			return distances[ids[a]] < distances[ids[b]]
+		}
+		return i.names[ids[a]] < i.names[ids[b]]
+	})
+	if len(ids) > maxSuggestions {
+		ids = ids[:maxSuggestions]
+	}
+
+	suggestions := make([]string, len(ids))
+	for n, id := range ids {
+		suggestions[n] = i.names[id]
+	}
+	return suggestions
+}
+
+// indexKey Name lower-cased, without spaces nor punctuation (e.g. kaisa for Kai'Sa, nunuwillump for Nunu & Willump)
+func indexKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// editDistance Number of insertions, deletions, substitutions and transpositions of two adjacent letters turning a into b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
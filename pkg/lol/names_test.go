@@ -0,0 +1,118 @@
+package lol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestChampionIndex() *ChampionIndex {
+	index := NewChampionIndex()
+	index.Add("Jhin", "Jhin")
+	index.Add("Jinx", "Jinx")
+	index.Add("Kaisa", "Kai'Sa")
+	index.Add("MonkeyKing", "Wukong")
+	index.Add("Nunu", "Nunu & Willump", "nunu and willump")
+	index.Add("MissFortune", "Miss Fortune", "mf")
+	index.Add("TwistedFate", "Twisted Fate", "tf")
+	return index
+}
+
+func TestChampionIndexResolve(t *testing.T) {
+	index := getTestChampionIndex()
+
+	for name, expected := range map[string]string{
+		"jhin":             "Jhin",
+		"JHIN":             "Jhin",
+		"Kai'Sa":           "Kaisa",
+		"kaisa":            "Kaisa",
+		"Wukong":           "MonkeyKing",
+		"monkeyking":       "MonkeyKing",
+		"Nunu & Willump":   "Nunu",
+		"nunu and willump": "Nunu",
+		"nunu":             "Nunu",
+		"MF":               "MissFortune",
+		"twisted fate":     "TwistedFate",
+		"twisted-fate":     "TwistedFate",
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := index.Resolve(name)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, id)
+		})
+	}
+
+	t.Run("typo", func(t *testing.T) {
+		_, err := index.Resolve("jihn")
+
+		assert.True(t, errors.Is(err, ErrChampionNotFound))
+		var unknownErr *UnknownChampionError
+		assert.True(t, errors.As(err, &unknownErr))
+		assert.Equal(t, []string{"Jhin", "Jinx"}, unknownErr.Suggestions)
+		assert.Equal(t, "champion not found: jihn (did you mean Jhin, Jinx?)", err.Error())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := index.Resolve("teemo")
+
+		assert.Equal(t, &UnknownChampionError{Name: "teemo", Suggestions: []string{}}, err)
+		assert.Equal(t, "champion not found: teemo", err.Error())
+	})
+}
+
+func TestChampionIndexSuggest(t *testing.T) {
+	index := getTestChampionIndex()
+
+	t.Run("swapped letters", func(t *testing.T) {
+		assert.Equal(t, []string{"Kai'Sa"}, index.Suggest("kiasa"))
+	})
+
+	t.Run("beginning of the name", func(t *testing.T) {
+		assert.Equal(t, []string{"Twisted Fate"}, index.Suggest("twist"))
+	})
+
+	t.Run("too short to be the beginning of a name", func(t *testing.T) {
+		assert.Empty(t, index.Suggest("ji"))
+	})
+
+	t.Run("too far", func(t *testing.T) {
+		assert.Empty(t, index.Suggest("xyz"))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, index.Suggest("?!"))
+	})
+}
+
+func TestNewChampionIndexFromRepository(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewDirRepository(dir)
+	for _, champion := range []Champion{
+		{ID: "MonkeyKing", Name: "Wukong", Stats: Stats{HealthPoints: 610}, Spells: []Spell{{ID: "aa", MaxRank: 1, Damage: []float64{68}, Cooldown: []float64{0.7}}}},
+		{ID: "MissFortune", Name: "Miss Fortune", Stats: Stats{HealthPoints: 570}, Spells: []Spell{{ID: "aa", MaxRank: 1, Damage: []float64{53}, Cooldown: []float64{0.65}}}},
+	} {
+		assert.Nil(t, repo.WriteChampion(champion))
+	}
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "jhin.yml"), []byte("id: Jhin\nspells: []\n"), 0600))
+
+	index, err := NewChampionIndexFromRepository(repo)
+	assert.Nil(t, err)
+
+	for name, expected := range map[string]string{
+		"wukong":       "MonkeyKing",
+		"mf":           "MissFortune",
+		"Miss Fortune": "MissFortune",
+		"Jhin":         "jhin", // invalid, indexed by its file name only
+	} {
+		id, err := index.Resolve(name)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, id)
+
+		_, err = repo.ReadChampion(id)
+		assert.True(t, err == nil || errors.Is(err, ErrInvalidChampion))
+	}
+}
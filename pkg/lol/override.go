@@ -0,0 +1,201 @@
+package lol
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v2"
+)
+
+const DefaultOverridesDir = "champions/overrides" // champion overrides directory, relative to the repository root
+
+// OverrideRepository ChampionRepository merging hand-written corrections on top of the champions of another one when reading them,
+// so that they survive any new download. Each override is a partial champion file (e.g. overrides/jhin.yml, of any format) holding only the fields
+// to change: maps are merged field by field, list elements with an id (or type) are merged with the element of the same id, anything else is replaced
+type OverrideRepository struct {
+	base      ChampionRepository
+	overrides fs.FS
+}
+
+func NewOverrideRepository(base ChampionRepository, overrides fs.FS) ChampionRepository {
+	return &OverrideRepository{base: base, overrides: overrides}
+}
+
+func (r *OverrideRepository) ReadChampion(name string) (Champion, error) {
+	override, file, format, err := readChampionFile(r.overrides, ".", name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r.base.ReadChampion(name)
+	}
+	if err == nil {
+		override, err = championFileToYAML(override, format)
+	}
+	if err != nil {
+		return Champion{}, fmt.Errorf("reading override of %s: %w", name, err)
+	}
+
+	// The champion is validated once overridden, so that an override can fix an invalid champion file
+	var champion Champion
+	var issues []ValidationIssue
+	if fileReader, ok := r.base.(ChampionFileReader); ok {
+		data, err := fileReader.ReadChampionFile(name)
+		if err != nil {
+			return Champion{}, err
+		}
+		champion, issues = decodeChampion(data, false)
+	} else {
+		champion, err = r.base.ReadChampion(name)
+		if err != nil {
+			return Champion{}, err
+		}
+	}
+
+	champion, err = applyOverride(champion, override)
+	if err != nil {
+		return Champion{}, fmt.Errorf("applying override of %s: %w", name, err)
+	}
+
+	issues = append(issues, ValidateChampion(champion)...)
+	if len(issues) > 0 {
+		return Champion{}, &ValidationError{Champion: name, File: file + " with its override", Issues: issues}
+	}
+	return champion, nil
+}
+
+// ReadChampionFile Raw champion file of the underlying repository (if it has any), i.e. without override
+func (r *OverrideRepository) ReadChampionFile(name string) ([]byte, error) {
+	fileReader, ok := r.base.(ChampionFileReader)
+	if !ok {
+		return nil, fmt.Errorf("champion files cannot be read from %T", r.base)
+	}
+	return fileReader.ReadChampionFile(name)
+}
+
+// WriteChampion Write to the underlying repository, overrides being left untouched
+func (r *OverrideRepository) WriteChampion(champion Champion) error {
+	return r.base.WriteChampion(champion)
+}
+
+func (r *OverrideRepository) ListChampions() ([]string, error) {
+	return r.base.ListChampions()
+}
+
+// OverridePatchStore PatchStore applying the same overrides to the champions of every patch
+type OverridePatchStore struct {
+	PatchStore
+	overrides fs.FS
+}
+
+func NewOverridePatchStore(store PatchStore, overrides fs.FS) PatchStore {
+	return &OverridePatchStore{PatchStore: store, overrides: overrides}
+}
+
+func (s *OverridePatchStore) Patch(patch string) ChampionRepository {
+	return NewOverrideRepository(s.PatchStore.Patch(patch), s.overrides)
+}
+
+// WithoutOverrides Store of the champions data as downloaded, i.e. with no override applied
+func WithoutOverrides(store PatchStore) PatchStore {
+	if overrideStore, ok := store.(*OverridePatchStore); ok {
+		return overrideStore.PatchStore
+	}
+	return store
+}
+
+func applyOverride(champion Champion, override []byte) (Champion, error) {
+	// Check the override on its own first, so that a misspelled field is reported rather than silently ignored
+	err := yaml.UnmarshalStrict(override, &Champion{})
+	if err != nil {
+		return Champion{}, err
+	}
+
+	var overrideValues interface{}
+	err = yaml.Unmarshal(override, &overrideValues)
+	if err != nil {
+		return Champion{}, err
+	}
+
+	data, err := yaml.Marshal(&champion)
+	if err != nil {
+		return Champion{}, err
+	}
+	var championValues interface{}
+	err = yaml.Unmarshal(data, &championValues)
+	if err != nil {
+		return Champion{}, err
+	}
+
+	data, err = yaml.Marshal(mergeYAML(championValues, overrideValues))
+	if err != nil {
+		return Champion{}, err
+	}
+	return unmarshalChampion(data)
+}
+
+// mergeYAML Override merged on top of base, both being decoded YAML values
+func mergeYAML(base, override interface{}) interface{} {
+	switch override := override.(type) {
+	case map[interface{}]interface{}:
+		baseMap, ok := base.(map[interface{}]interface{})
+		if !ok {
+			return override
+		}
+		merged := make(map[interface{}]interface{}, len(baseMap))
+		for k, v := range baseMap {
+			merged[k] = v
+		}
+		for k, v := range override {
+			merged[k] = mergeYAML(baseMap[k], v)
+		}
+		return merged
+	case []interface{}:
+		baseList, ok := base.([]interface{})
+		if !ok {
+			return override
+		}
+		return mergeYAMLLists(baseList, override)
+	default:
+		return override
+	}
+}
+
+// mergeYAMLLists Override elements merged with the base elements of the same key (e.g. spell id), appended if there is none.
+// The whole list is replaced if any override element has no key (e.g. damage per rank)
+func mergeYAMLLists(base, override []interface{}) []interface{} {
+	merged := make([]interface{}, len(base))
+	copy(merged, base)
+
+	for _, overrideElem := range override {
+		key, ok := yamlElemKey(overrideElem)
+		if !ok {
+			return override
+		}
+
+		found := false
+		for i, baseElem := range merged {
+			if baseKey, ok := yamlElemKey(baseElem); ok && baseKey == key {
+				merged[i] = mergeYAML(baseElem, overrideElem)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, overrideElem)
+		}
+	}
+	return merged
+}
+
+// yamlElemKey Id (or type) of a decoded YAML list element, as for the diff of champions
+func yamlElemKey(elem interface{}) (string, bool) {
+	m, ok := elem.(map[interface{}]interface{})
+	if !ok {
+		return "", false
+	}
+	for _, name := range []string{"id", "type"} {
+		if key, ok := m[name].(string); ok && key != "" {
+			return name + "=" + key, true
+		}
+	}
+	return "", false
+}
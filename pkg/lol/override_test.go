@@ -0,0 +1,135 @@
+package lol
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverrideRepository(t *testing.T) {
+	jhin := getMockChampion(withName("Jhin"), withPatch("13.1.1"), withStats(Stats{HealthPoints: 655, AttackDamage: 59}), withLocales(nil), withSpells(
+		Spell{ID: "aa", MaxRank: 1, Damage: []float64{59}, Cooldown: []float64{0.625}},
+		Spell{ID: "JhinQ", MaxRank: 5, Damage: []float64{0, 0, 0, 0, 0}, Cooldown: []float64{9, 7.5, 6, 4.5, 3}},
+		Spell{ID: "JhinW", MaxRank: 5, Damage: []float64{0, 0, 0, 0, 0}, Cooldown: []float64{12, 12, 12, 12, 12}, CC: []CrowdControl{{Type: CCRoot, Duration: []float64{1, 1, 1, 1, 1}}}},
+	))
+
+	t.Run("field level merge", func(t *testing.T) {
+		overrides := fstest.MapFS{"jhin.yml": {Data: []byte(`
+stats:
+  health_points: 630
+spells:
+  - id: JhinQ
+    damage: [45, 70, 95, 120, 145]
+  - id: JhinW
+    cc:
+      - type: root
+        duration: [1.25, 1.5, 1.75, 2, 2.25]
+  - id: JhinE
+    max_rank: 5
+    damage: [20, 80, 140, 200, 260]
+    cooldown: [2, 2, 2, 2, 2]
+`)}}
+		repo := NewOverrideRepository(NewMemoryRepository(jhin), overrides)
+
+		champion, err := repo.ReadChampion("jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 630.0, champion.Stats.HealthPoints)
+		assert.Equal(t, 59.0, champion.Stats.AttackDamage) // not overridden, kept
+		assert.Equal(t, "13.1.1", champion.Patch)
+		assert.Len(t, champion.Spells, 4)
+		assert.Equal(t, []float64{59}, champion.Spells[0].Damage)
+		assert.Equal(t, []float64{45, 70, 95, 120, 145}, champion.Spells[1].Damage)
+		assert.Equal(t, []float64{9, 7.5, 6, 4.5, 3}, champion.Spells[1].Cooldown) // not overridden, kept
+		assert.Equal(t, []CrowdControl{{Type: CCRoot, Duration: []float64{1.25, 1.5, 1.75, 2, 2.25}}}, champion.Spells[2].CC)
+		assert.Equal(t, "JhinE", champion.Spells[3].ID)
+	})
+
+	t.Run("override fixing an invalid champion file", func(t *testing.T) {
+		base := NewFSRepository(fstest.MapFS{"jhin.yml": {Data: []byte("id: Jhin\nname: Jhin\nstats:\n  health_points: 655\nspells:\n- id: aa\n  max_rank: 1\n  damage: [59]\n  cooldown: []\n")}}, ".")
+		overrides := fstest.MapFS{"jhin.yml": {Data: []byte("spells:\n  - id: aa\n    cooldown: [1.6]\n")}}
+
+		_, err := base.ReadChampion("jhin")
+		assert.True(t, errors.Is(err, ErrInvalidChampion))
+
+		champion, err := NewOverrideRepository(base, overrides).ReadChampion("jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []float64{1.6}, champion.Spells[0].Cooldown)
+	})
+
+	t.Run("override making a champion invalid", func(t *testing.T) {
+		overrides := fstest.MapFS{"jhin.yml": {Data: []byte("spells:\n  - id: JhinQ\n    damage: [45]\n")}}
+		repo := NewOverrideRepository(NewMemoryRepository(jhin), overrides)
+
+		_, err := repo.ReadChampion("jhin")
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []ValidationIssue{{Field: "spells[JhinQ].damage", Spell: "JhinQ", Message: "has 1 values, fewer than max_rank (5)"}}, validationErr.Issues)
+	})
+
+	t.Run("no override", func(t *testing.T) {
+		repo := NewOverrideRepository(NewMemoryRepository(jhin), fstest.MapFS{})
+
+		champion, err := repo.ReadChampion("jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, jhin, champion)
+	})
+
+	t.Run("override of a missing champion", func(t *testing.T) {
+		overrides := fstest.MapFS{"ahri.yml": {Data: []byte("stats:\n  health_points: 590\n")}}
+		repo := NewOverrideRepository(NewMemoryRepository(jhin), overrides)
+
+		_, err := repo.ReadChampion("ahri")
+
+		assert.True(t, errors.Is(err, ErrChampionNotFound))
+	})
+
+	t.Run("misspelled field", func(t *testing.T) {
+		overrides := fstest.MapFS{"jhin.yml": {Data: []byte("stats:\n  health_point: 630\n")}}
+		repo := NewOverrideRepository(NewMemoryRepository(jhin), overrides)
+
+		_, err := repo.ReadChampion("jhin")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("write leaves the override out", func(t *testing.T) {
+		overrides := fstest.MapFS{"jhin.yml": {Data: []byte("stats:\n  health_points: 630\n")}}
+		base := NewMemoryRepository()
+		repo := NewOverrideRepository(base, overrides)
+
+		err := repo.WriteChampion(jhin)
+		assert.Nil(t, err)
+
+		stored, err := base.ReadChampion("jhin")
+		assert.Nil(t, err)
+		assert.Equal(t, 655.0, stored.Stats.HealthPoints)
+		champion, err := repo.ReadChampion("jhin")
+		assert.Nil(t, err)
+		assert.Equal(t, 630.0, champion.Stats.HealthPoints)
+	})
+}
+
+func TestOverridePatchStore(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, NewDirRepository(dir+"/13.1.1").WriteChampion(getMockChampion(withName("Jhin"), withPatch("13.1.1"), withHealthPoints(655))))
+	overrides := fstest.MapFS{"jhin.yml": {Data: []byte("stats:\n  health_points: 630\n")}}
+	store := NewOverridePatchStore(NewDirPatchStore(dir), overrides)
+
+	patches, err := store.ListPatches()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"13.1.1"}, patches)
+
+	champion, err := store.Patch("13.1.1").ReadChampion("jhin")
+	assert.Nil(t, err)
+	assert.Equal(t, 630.0, champion.Stats.HealthPoints)
+
+	champion, err = WithoutOverrides(store).Patch("13.1.1").ReadChampion("jhin")
+	assert.Nil(t, err)
+	assert.Equal(t, 655.0, champion.Stats.HealthPoints)
+}
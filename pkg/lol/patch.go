@@ -0,0 +1,167 @@
+//go:generate mockery --case underscore --dir . --name PatchStore --output ./mocks
+
+package lol
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrPatchNotFound = errors.New("patch not found")
+
+// PatchStore Champions data of several patches side by side, each patch in its own directory named after it (e.g. champions/lol/14.20.1/ahri.yml)
+type PatchStore interface {
+	Patch(patch string) ChampionRepository
+	ResolvePatch(patch string) (string, error)
+	ListPatches() (patches []string, err error)
+	RemovePatch(patch string) error
+}
+
+// DirPatchStore PatchStore keeping each patch in a sub-directory of dir
+type DirPatchStore struct {
+	dir string
+}
+
+func NewDirPatchStore(dir string) PatchStore {
+	return &DirPatchStore{dir: dir}
+}
+
+// Patch Champions data of the given patch (which must be an exact one, see ResolvePatch)
+func (s *DirPatchStore) Patch(patch string) ChampionRepository {
+	return NewDirRepository(filepath.Join(s.dir, patch))
+}
+
+func (s *DirPatchStore) ResolvePatch(patch string) (string, error) {
+	patches, err := s.ListPatches()
+	if err != nil {
+		return "", err
+	}
+	return ResolvePatch(patches, patch)
+}
+
+func (s *DirPatchStore) ListPatches() ([]string, error) {
+	patches, err := listPatchDirs(os.DirFS(s.dir), ".")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return patches, err
+}
+
+func (s *DirPatchStore) RemovePatch(patch string) error {
+	patches, err := s.ListPatches()
+	if err != nil {
+		return err
+	}
+	if !containsPatch(patches, patch) {
+		return fmt.Errorf("%w: %s (in %s)", ErrPatchNotFound, patch, s.dir)
+	}
+	return os.RemoveAll(filepath.Join(s.dir, patch))
+}
+
+// FSPatchStore Read-only PatchStore on top of any file system (e.g. the champions data snapshot embedded into the binary)
+type FSPatchStore struct {
+	fsys fs.FS
+	dir  string
+}
+
+func NewFSPatchStore(fsys fs.FS, dir string) PatchStore {
+	return &FSPatchStore{fsys: fsys, dir: dir}
+}
+
+func (s *FSPatchStore) Patch(patch string) ChampionRepository {
+	return NewFSRepository(s.fsys, path.Join(s.dir, patch))
+}
+
+func (s *FSPatchStore) ResolvePatch(patch string) (string, error) {
+	patches, err := s.ListPatches()
+	if err != nil {
+		return "", err
+	}
+	return ResolvePatch(patches, patch)
+}
+
+func (s *FSPatchStore) ListPatches() ([]string, error) {
+	return listPatchDirs(s.fsys, s.dir)
+}
+
+func (s *FSPatchStore) RemovePatch(_ string) error {
+	return ErrReadOnlyRepository
+}
+
+// listPatchDirs Patches stored in dir (i.e. the sub-directories named after a version), from the oldest to the latest
+func listPatchDirs(fsys fs.FS, dir string) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var patches []string
+	for _, e := range entries {
+		if e.IsDir() && IsPatch(e.Name()) {
+			patches = append(patches, e.Name())
+		}
+	}
+	sort.Slice(patches, func(i, j int) bool {
+		return ComparePatches(patches[i], patches[j]) < 0
+	})
+	return patches, nil
+}
+
+// ResolvePatch Exact patch among the given ones (from the oldest to the latest) matching the given one: the latest one if empty,
+// otherwise either the very same patch or the latest one it is a prefix of (e.g. 14.20 resolves to 14.20.1)
+func ResolvePatch(patches []string, patch string) (string, error) {
+	if len(patches) == 0 {
+		return "", fmt.Errorf("%w: no patch stored", ErrPatchNotFound)
+	}
+	if patch == "" {
+		return patches[len(patches)-1], nil
+	}
+	for i := len(patches) - 1; i >= 0; i-- {
+		if patches[i] == patch || strings.HasPrefix(patches[i], patch+".") {
+			return patches[i], nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrPatchNotFound, patch)
+}
+
+func containsPatch(patches []string, patch string) bool {
+	for _, p := range patches {
+		if p == patch {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPatch Whether name looks like a data dragon version, i.e. dot separated numbers (e.g. 14.20.1)
+func IsPatch(name string) bool {
+	for _, n := range strings.Split(name, ".") {
+		if _, err := strconv.Atoi(n); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ComparePatches Compare two patches number by number, so that 14.9 comes before 14.20
+func ComparePatches(a, b string) int {
+	na, nb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(na) && i < len(nb); i++ {
+		x, _ := strconv.Atoi(na[i])
+		y, _ := strconv.Atoi(nb[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(na) - len(nb)
+}
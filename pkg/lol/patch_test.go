@@ -0,0 +1,105 @@
+package lol
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirPatchStore(t *testing.T) {
+	t.Run("write, list and resolve", func(t *testing.T) {
+		store := NewDirPatchStore(t.TempDir())
+		for _, patch := range []string{"14.20.1", "14.9.1", "14.19.1"} {
+			err := store.Patch(patch).WriteChampion(getMockChampion())
+			assert.Nil(t, err)
+		}
+
+		patches, err := store.ListPatches()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"14.9.1", "14.19.1", "14.20.1"}, patches)
+
+		patch, err := store.ResolvePatch("")
+		assert.Nil(t, err)
+		assert.Equal(t, "14.20.1", patch)
+
+		patch, err = store.ResolvePatch("14.19")
+		assert.Nil(t, err)
+		assert.Equal(t, "14.19.1", patch)
+
+		champion, err := store.Patch("14.9.1").ReadChampion("twistedfate")
+		assert.Nil(t, err)
+		assert.Equal(t, getMockChampion(), champion)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		store := NewDirPatchStore(t.TempDir())
+		err := store.Patch("14.20.1").WriteChampion(getMockChampion())
+		assert.Nil(t, err)
+
+		err = store.RemovePatch("14.20.1")
+		assert.Nil(t, err)
+
+		patches, err := store.ListPatches()
+		assert.Nil(t, err)
+		assert.Empty(t, patches)
+
+		err = store.RemovePatch("14.20.1")
+		assert.True(t, errors.Is(err, ErrPatchNotFound))
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		store := NewDirPatchStore(t.TempDir() + "/lol")
+
+		patches, err := store.ListPatches()
+		assert.Nil(t, err)
+		assert.Empty(t, patches)
+
+		_, err = store.ResolvePatch("")
+		assert.True(t, errors.Is(err, ErrPatchNotFound))
+	})
+}
+
+func TestFSPatchStore(t *testing.T) {
+	twistedFate := []byte("id: TwistedFate\nname: Twisted Fate\nstats:\n  health_points: 604\nspells:\n- {id: aa, max_rank: 1, damage: [52], cooldown: [0]}\n")
+	fsys := fstest.MapFS{
+		"lol/12.3.1/twistedfate.yml": {Data: twistedFate},
+		"lol/13.1.1/twistedfate.yml": {Data: twistedFate},
+		"lol/notes/readme.yml":       {Data: []byte{}},
+		"lol/.gitkeep":               {Data: []byte{}},
+	}
+	store := NewFSPatchStore(fsys, "lol")
+
+	t.Run("list", func(t *testing.T) {
+		patches, err := store.ListPatches()
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"12.3.1", "13.1.1"}, patches)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		champion, err := store.Patch("12.3.1").ReadChampion("twistedfate")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Twisted Fate", champion.Name)
+	})
+
+	t.Run("unknown patch", func(t *testing.T) {
+		_, err := store.ResolvePatch("14.20")
+
+		assert.True(t, errors.Is(err, ErrPatchNotFound))
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		err := store.RemovePatch("12.3.1")
+
+		assert.True(t, errors.Is(err, ErrReadOnlyRepository))
+	})
+}
+
+func TestComparePatches(t *testing.T) {
+	assert.Negative(t, ComparePatches("14.9.1", "14.20.1"))
+	assert.Positive(t, ComparePatches("14.20.1", "14.20"))
+	assert.Zero(t, ComparePatches("12.3.1", "12.3.1"))
+}
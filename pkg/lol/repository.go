@@ -0,0 +1,254 @@
+//go:generate mockery --case underscore --dir . --name ChampionRepository --output ./mocks
+
+package lol
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+const DefaultChampionsDir = "champions/lol" // champions data directory, relative to the repository root
+
+var (
+	ErrChampionNotFound   = errors.New("champion not found")
+	ErrReadOnlyRepository = errors.New("champion repository is read-only")
+)
+
+// ChampionRepository Storage champions data are read from and written to, where champions are looked up by name (case and spaces do not matter)
+type ChampionRepository interface {
+	ReadChampion(name string) (champion Champion, err error)
+	WriteChampion(champion Champion) error
+	ListChampions() (names []string, err error)
+}
+
+// DirRepository ChampionRepository storing each champion in its own file inside a directory, in YAML unless the champion file is
+// already in another format (see ConvertChampion). Files are strictly decoded and validated when read, a *ValidationError telling
+// what is wrong with an invalid one
+type DirRepository struct {
+	dir string
+}
+
+func NewDirRepository(dir string) ChampionRepository {
+	return &DirRepository{dir: dir}
+}
+
+func (r *DirRepository) ReadChampion(name string) (champion Champion, err error) {
+	data, file, format, err := readChampionFile(os.DirFS(r.dir), ".", name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Champion{}, fmt.Errorf("%w: %s (in %s)", ErrChampionNotFound, name, r.dir)
+	}
+	if err != nil {
+		return Champion{}, err
+	}
+	return loadChampion(name, filepath.Join(r.dir, file), format, data)
+}
+
+// ReadChampionFile Content of the champion file, converted to YAML if it is in another format
+func (r *DirRepository) ReadChampionFile(name string) ([]byte, error) {
+	data, _, format, err := readChampionFile(os.DirFS(r.dir), ".", name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s (in %s)", ErrChampionNotFound, name, r.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return championFileToYAML(data, format)
+}
+
+// WriteChampion Write the champion in the format of its current file, if any
+func (r *DirRepository) WriteChampion(champion Champion) error {
+	format := championFileFormatIn(os.DirFS(r.dir), ".", championKey(champion))
+	if format == "" {
+		format = FormatYAML
+	}
+	return r.writeChampion(champion, format)
+}
+
+// ConvertChampion Rewrite the champion file in the given format, removing the file in its previous format
+func (r *DirRepository) ConvertChampion(name, format string) error {
+	if !isChampionFileFormat(format) {
+		return fmt.Errorf("unknown champion file format %q", format)
+	}
+
+	champion, err := r.ReadChampion(name)
+	if err != nil {
+		return err
+	}
+	previous := championFileFormatIn(os.DirFS(r.dir), ".", name)
+	if previous == format {
+		return nil
+	}
+
+	err = r.writeChampion(champion, format)
+	if err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(r.dir, championFileName(name, previous)))
+}
+
+func (r *DirRepository) writeChampion(champion Champion, format string) error {
+	champion.Version = CurrentSchemaVersion
+	data, err := MarshalChampion(champion, format)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(r.dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(r.dir, championFileName(championKey(champion), format)), data, 0700)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *DirRepository) ListChampions() ([]string, error) {
+	return listChampionFiles(os.DirFS(r.dir), ".")
+}
+
+// FSRepository Read-only ChampionRepository on top of any file system (e.g. the champions data snapshot embedded into the binary)
+type FSRepository struct {
+	fsys fs.FS
+	dir  string
+}
+
+func NewFSRepository(fsys fs.FS, dir string) ChampionRepository {
+	return &FSRepository{fsys: fsys, dir: dir}
+}
+
+func (r *FSRepository) ReadChampion(name string) (champion Champion, err error) {
+	data, file, format, err := readChampionFile(r.fsys, r.dir, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Champion{}, fmt.Errorf("%w: %s", ErrChampionNotFound, name)
+	}
+	if err != nil {
+		return Champion{}, err
+	}
+	return loadChampion(name, path.Join(r.dir, file), format, data)
+}
+
+// ReadChampionFile Content of the champion file, converted to YAML if it is in another format
+func (r *FSRepository) ReadChampionFile(name string) ([]byte, error) {
+	data, _, format, err := readChampionFile(r.fsys, r.dir, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrChampionNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return championFileToYAML(data, format)
+}
+
+func (r *FSRepository) WriteChampion(_ Champion) error {
+	return ErrReadOnlyRepository
+}
+
+func (r *FSRepository) ListChampions() ([]string, error) {
+	return listChampionFiles(r.fsys, r.dir)
+}
+
+// MemoryRepository ChampionRepository keeping champions in memory, mostly useful for tests
+type MemoryRepository struct {
+	mu        sync.RWMutex
+	champions map[string]Champion
+}
+
+func NewMemoryRepository(champions ...Champion) ChampionRepository {
+	r := &MemoryRepository{champions: map[string]Champion{}}
+	for _, champion := range champions {
+		r.champions[StoredChampionName(champion)] = champion
+	}
+	return r
+}
+
+func (r *MemoryRepository) ReadChampion(name string) (Champion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	champion, ok := r.champions[normalizeChampionName(name)]
+	if !ok {
+		return Champion{}, fmt.Errorf("%w: %s", ErrChampionNotFound, name)
+	}
+	return champion, nil
+}
+
+func (r *MemoryRepository) WriteChampion(champion Champion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.champions[StoredChampionName(champion)] = champion
+	return nil
+}
+
+func (r *MemoryRepository) ListChampions() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.champions))
+	for name := range r.champions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func unmarshalChampion(data []byte) (champion Champion, err error) {
+	err = yaml.Unmarshal(data, &champion)
+	if err != nil {
+		return Champion{}, fmt.Errorf("error unmarshalling: %w", err)
+	}
+	return champion, nil
+}
+
+// listChampionFiles Names of the champions stored in dir, i.e. the champion files (of any format) without extension
+func listChampionFiles(fsys fs.FS, dir string) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), path.Ext(e.Name()))
+		if !e.IsDir() && isChampionFileFormat(strings.TrimPrefix(path.Ext(e.Name()), ".")) && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// championKey Name a champion is stored by, i.e. its riot internal name (falling back on the public one)
+func championKey(champion Champion) string {
+	if champion.ID != "" {
+		return champion.ID
+	}
+	return champion.Name
+}
+
+// StoredChampionName Name the champion file is stored by, without extension (e.g. monkeyking for MonkeyKing), as listed by ListChampions
+func StoredChampionName(champion Champion) string {
+	return normalizeChampionName(championKey(champion))
+}
+
+func championFileName(name, format string) string {
+	return fmt.Sprintf("%s.%s", normalizeChampionName(name), format)
+}
+
+func normalizeChampionName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "")
+}
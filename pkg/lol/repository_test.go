@@ -0,0 +1,152 @@
+package lol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirRepository(t *testing.T) {
+	t.Run("write and read", func(t *testing.T) {
+		repo := NewDirRepository(t.TempDir() + "/lol")
+
+		err := repo.WriteChampion(getMockChampion())
+		assert.Nil(t, err)
+
+		champion, err := repo.ReadChampion("Twisted Fate")
+		assert.Nil(t, err)
+		assert.Equal(t, getMockChampion(), champion)
+
+		names, err := repo.ListChampions()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"twistedfate"}, names)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := NewDirRepository(t.TempDir())
+
+		_, err := repo.ReadChampion("jhin")
+
+		assert.True(t, errors.Is(err, ErrChampionNotFound))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "jhin.yml"), []byte("id: Jhin\nname: Jhin\nstats:\n  health_points: 655\n  armour: 24\nspells:\n- id: aa\n  max_rank: 1\n  damage: []\n  cooldown: [1.6]\n"), 0600)
+		assert.Nil(t, err)
+		repo := NewDirRepository(dir)
+
+		_, err = repo.ReadChampion("jhin")
+
+		assert.True(t, errors.Is(err, ErrInvalidChampion))
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, filepath.Join(dir, "jhin.yml"), validationErr.File)
+		assert.Len(t, validationErr.Issues, 2)
+		assert.Contains(t, validationErr.Issues[0].Message, "armour")
+		assert.Equal(t, ValidationIssue{Field: "spells[aa].damage", Spell: "aa", Message: "has 0 values, fewer than max_rank (1)"}, validationErr.Issues[1])
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "jhin.yml"), []byte("id: [Jhin"), 0600)
+		assert.Nil(t, err)
+		repo := NewDirRepository(dir)
+
+		_, err = repo.ReadChampion("jhin")
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Issues, 1)
+	})
+}
+
+func TestFSRepository(t *testing.T) {
+	data, err := MarshalChampion(getMockChampion(), FormatYAML)
+	assert.Nil(t, err)
+	fsys := fstest.MapFS{
+		"lol/twistedfate.yml": {Data: data},
+		"lol/.gitkeep":        {Data: []byte{}},
+	}
+	repo := NewFSRepository(fsys, "lol")
+
+	t.Run("read", func(t *testing.T) {
+		champion, err := repo.ReadChampion("twistedfate")
+
+		assert.Nil(t, err)
+		assert.Equal(t, getMockChampion(), champion)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		names, err := repo.ListChampions()
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"twistedfate"}, names)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.ReadChampion("jhin")
+
+		assert.True(t, errors.Is(err, ErrChampionNotFound))
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		err := repo.WriteChampion(getMockChampion())
+
+		assert.True(t, errors.Is(err, ErrReadOnlyRepository))
+	})
+}
+
+func TestMemoryRepository(t *testing.T) {
+	repo := NewMemoryRepository(getMockChampion())
+
+	t.Run("read", func(t *testing.T) {
+		champion, err := repo.ReadChampion("TWISTEDFATE")
+
+		assert.Nil(t, err)
+		assert.Equal(t, getMockChampion(), champion)
+	})
+
+	t.Run("write and list", func(t *testing.T) {
+		err := repo.WriteChampion(Champion{ID: "Jhin", Name: "Jhin"})
+		assert.Nil(t, err)
+
+		names, err := repo.ListChampions()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"jhin", "twistedfate"}, names)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.ReadChampion("ahri")
+
+		assert.True(t, errors.Is(err, ErrChampionNotFound))
+	})
+}
+
+func TestChampionFileName(t *testing.T) {
+	t.Run("lowercase name", func(t *testing.T) {
+		assert.Equal(t, "name.yml", championFileName("name", FormatYAML))
+	})
+
+	t.Run("uppercase name", func(t *testing.T) {
+		assert.Equal(t, "name.json", championFileName("NAME", FormatJSON))
+	})
+
+	t.Run("multi case plus spaces", func(t *testing.T) {
+		assert.Equal(t, "somename.toml", championFileName("SomE nAme", FormatTOML))
+	})
+}
+
+func TestStoredChampionName(t *testing.T) {
+	t.Run("by id", func(t *testing.T) {
+		assert.Equal(t, "monkeyking", StoredChampionName(Champion{ID: "MonkeyKing", Name: "Wukong"}))
+	})
+
+	t.Run("by name if no id", func(t *testing.T) {
+		assert.Equal(t, "twistedfate", StoredChampionName(Champion{Name: "Twisted Fate"}))
+	})
+}
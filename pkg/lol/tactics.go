@@ -1,4 +1,4 @@
-//go:generate mockery --case underscore --dir . --name Tactics --output ./mocks
+//go:generate mockery --case underscore --dir . --name Solver --output ./mocks
 
 package lol
 
@@ -10,10 +10,17 @@ import (
 
 //TODO: only considering spell's max rank atm (i.e. spell.MaxRank-1), but need to consider all (e.g. 'q' has 5 ranks, etc.)
 
+// Solver Find out how fights between champions end
+type Solver interface {
+	Fight(champion1, champion2 Champion) (TacticsSol, error)
+	Duel(champion1, champion2 Champion, opts DuelOptions) DuelSol
+	TeamFight(team1, team2 []Champion, opts TeamFightOptions) (TeamFightSol, error)
+}
+
+// Tactics Champion repository and solver bundled together
 type Tactics interface {
-	ReadChampion(filePath string) (champion Champion, err error)
-	WriteChampion(champion Champion, filePath string) error
-	Fight(champion1, champion2 Champion) TacticsSol
+	ChampionRepository
+	Solver
 }
 
 type TacticsSol struct {
@@ -25,12 +32,34 @@ type FightTactics struct {
 	log logger.Logger
 }
 
-func NewTactics(log logger.Logger) Tactics {
+type tactics struct {
+	ChampionRepository
+	Solver
+}
+
+// NewSolver Default Solver
+func NewSolver(log logger.Logger) Solver {
 	return &FightTactics{log: log}
 }
 
-// Fight Champion1 vs Champion2 health point
-func (f *FightTactics) Fight(champion1, champion2 Champion) TacticsSol {
+// NewTactics Default ChampionRepository (i.e. the latest patch of the champions data directory) and Solver bundled together
+func NewTactics(log logger.Logger) Tactics {
+	championStore := NewDirPatchStore(DefaultChampionsDir)
+	championRepo := NewDirRepository(DefaultChampionsDir)
+	if patch, err := championStore.ResolvePatch(""); err == nil {
+		championRepo = championStore.Patch(patch)
+	}
+	return &tactics{ChampionRepository: championRepo, Solver: NewSolver(log)}
+}
+
+// Fight Champion1 vs Champion2 health point, both champions having to be valid (see ValidateChampion)
+func (f *FightTactics) Fight(champion1, champion2 Champion) (TacticsSol, error) {
+	for _, champion := range []Champion{champion1, champion2} {
+		if issues := ValidateChampion(champion); len(issues) > 0 {
+			return TacticsSol{}, &ValidationError{Champion: champion.Name, Issues: issues}
+		}
+	}
+
 	var sol []Spell
 	var bestSol = TacticsSol{Benchmark: math.MaxFloat64, RoundOfSpells: []Spell{}}
 
@@ -38,7 +67,7 @@ func (f *FightTactics) Fight(champion1, champion2 Champion) TacticsSol {
 
 	f.log.Printf("[%s vs %s] Best solution found: enemy slayed in %.2fs\n", champion1.Name, champion2.Name, bestSol.Benchmark)
 
-	return bestSol
+	return bestSol, nil
 }
 
 func (f *FightTactics) getBestRoundOfSpells(pos int, spells, sol []Spell, hp float64, bestSol *TacticsSol) {
@@ -48,8 +77,8 @@ func (f *FightTactics) getBestRoundOfSpells(pos int, spells, sol []Spell, hp flo
 	}
 
 	for i := 0; i < len(spells); i++ {
-		if spells[i].Damage[0] != 0 {
-			// TODO: excluding spells with zero damage atm, but need to take their passive into account
+		if spellDamage(spells[i]) > 0 {
+			// TODO: excluding spells with no damage atm, but need to take their passive into account
 			sol = append(sol, spells[i])
 			f.getBestRoundOfSpells(pos+1, spells, sol, hp, bestSol)
 			sol = sol[:len(sol)-1] // pop value
@@ -82,12 +111,24 @@ func (f *FightTactics) setBenchmark(spells []Spell, bestSol *TacticsSol) {
 
 // getBenchmark Given a set of spells (which brings the hp to zero), return the relevant benchmark (i.e. time needed to kill the enemy)
 func getBenchmark(spells []Spell) (benchmark float64) {
+	if times := SpellTimes(spells); len(times) > 0 {
+		benchmark = times[len(times)-1]
+	}
+	return benchmark
+}
+
+// SpellTimes Time (in seconds since the fight started) each spell of a round of spells has been cast at, i.e. once it has waited for
+// its cooldown (if used before) and for its cast time. The last one is the benchmark of the round
+func SpellTimes(spells []Spell) []float64 {
+	times := make([]float64, len(spells))
 	usedSpells := make([]Spell, len(spells))
+	var t float64
 	for i, s := range spells {
-		benchmark += s.Cast + getAdditionalTimeIfSpellIsInCooldown(s, usedSpells)
+		t += s.Cast + getAdditionalTimeIfSpellIsInCooldown(s, usedSpells)
+		times[i] = t
 		usedSpells[i] = s
 	}
-	return benchmark
+	return times
 }
 
 // getAdditionalTimeIfSpellIsInCooldown Get additional waiting time if the spell has been used previously and is therefore still in cooldown.
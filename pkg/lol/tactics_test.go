@@ -1,15 +1,15 @@
 package lol
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
 	"github.com/stretchr/testify/assert"
-
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -25,14 +25,134 @@ func readMockTestChampion(mockFileName string) (champion Champion, err error) {
 		return Champion{}, err
 	}
 
-	err = yaml.Unmarshal(yamlFile, &champion)
-	if err != nil {
-		return Champion{}, fmt.Errorf("error unmarshalling: %w", err)
+	// Mock champions are of schema version 1.0.0, migrated as any other champion file
+	champion, issues := decodeChampion(yamlFile, false)
+	if len(issues) > 0 {
+		return Champion{}, &ValidationError{Champion: mockFileName, Issues: issues}
 	}
 
 	return champion, nil
 }
 
+// mockChampionOption Change to the mock champion, for the fields a test depends on
+type mockChampionOption func(*Champion)
+
+// withName Mock champion of the given name, its id being the name without spaces
+func withName(name string) mockChampionOption {
+	return func(champion *Champion) {
+		champion.ID = strings.ReplaceAll(name, " ", "")
+		champion.Name = name
+	}
+}
+
+func withPatch(patch string) mockChampionOption {
+	return func(champion *Champion) { champion.Patch = patch }
+}
+
+// withLocale Mock champion downloaded in the given locale, where it has the given title
+func withLocale(locale, title string) mockChampionOption {
+	return func(champion *Champion) { champion.Locale, champion.Title = locale, title }
+}
+
+func withHealthPoints(hp float64) mockChampionOption {
+	return func(champion *Champion) { champion.Stats.HealthPoints = hp }
+}
+
+func withLocales(locales map[string]Localization) mockChampionOption {
+	return func(champion *Champion) { champion.Locales = locales }
+}
+
+func withStats(stats Stats) mockChampionOption {
+	return func(champion *Champion) { champion.Stats = stats }
+}
+
+func withSpells(spells ...Spell) mockChampionOption {
+	return func(champion *Champion) { champion.Spells = spells }
+}
+
+// getMockChampion Valid champion of the current schema version, changed by the given options
+func getMockChampion(opts ...mockChampionOption) Champion {
+	champion := Champion{
+		Version: CurrentSchemaVersion,
+		ID:      "TwistedFate",
+		Name:    "Twisted Fate",
+		Passive: Passive{Name: "Loaded Dice"},
+		Stats:   Stats{HealthPoints: 534},
+		Spells: []Spell{
+			{ID: "aa", Name: "Auto Attack", MaxRank: 1, Damage: []float64{52}, Cooldown: []float64{0}},
+			{
+				ID:       "WildCards",
+				Name:     "Wild Cards",
+				MaxRank:  5,
+				Damage:   []float64{60, 105, 150, 195, 240},
+				Ratios:   map[string][]float64{"APRatio": {0.7, 0.7, 0.7, 0.7, 0.7}},
+				Cooldown: []float64{6, 5.75, 5.5, 5.25, 5},
+				Cast:     0.25,
+				CC:       []CrowdControl{{Type: CCStun, Duration: []float64{1, 1.25, 1.5, 1.75, 2}}},
+			},
+		},
+		Locales: map[string]Localization{"it_IT": {Name: "Twisted Fate", Spells: map[string]string{"WildCards": "Carte Selvagge"}}},
+	}
+	for _, opt := range opts {
+		opt(&champion)
+	}
+	return champion
+}
+
+// withoutAutoAttack Spells but the auto attack, the one made out of the attack stats when migrating the mock champions
+func withoutAutoAttack(spells []Spell) []Spell {
+	var filtered []Spell
+	for _, spell := range spells {
+		if spell.ID != AutoAttackID {
+			filtered = append(filtered, spell)
+		}
+	}
+	return filtered
+}
+
+func TestFight(t *testing.T) {
+	fightTactics := FightTactics{&loggertest.Logger{}}
+	champion1 := Champion{
+		ID:    "Champion1",
+		Name:  "Champion1",
+		Stats: Stats{HealthPoints: 100},
+		Spells: []Spell{
+			{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{0}, Cast: 1},
+			{ID: "q", MaxRank: 2, Damage: []float64{20, 50}, Cooldown: []float64{10, 10}, Cast: 1},
+		},
+	}
+	champion2 := champion1
+	champion2.ID, champion2.Name = "Champion2", "Champion2"
+
+	t.Run("success", func(t *testing.T) {
+		sol, err := fightTactics.Fight(champion1, champion2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 6.0, sol.Benchmark) // q then 5 aa, rather than waiting for q again
+	})
+
+	t.Run("inconsistent spell", func(t *testing.T) {
+		invalid := champion1
+		invalid.Spells = []Spell{champion1.Spells[0], {ID: "q", MaxRank: 2, Damage: []float64{20}, Cooldown: []float64{10, 10}}}
+
+		_, err := fightTactics.Fight(invalid, champion2)
+
+		var validationErr *ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "Champion1", validationErr.Champion)
+		assert.Equal(t, []ValidationIssue{{Field: "spells[q].damage", Spell: "q", Message: "has 1 values, fewer than max_rank (2)"}}, validationErr.Issues)
+	})
+
+	t.Run("no spells", func(t *testing.T) {
+		invalid := champion2
+		invalid.Spells = nil
+
+		_, err := fightTactics.Fight(champion1, invalid)
+
+		assert.True(t, errors.Is(err, ErrInvalidChampion))
+	})
+}
+
 func TestGetBestRoundOfSpells(t *testing.T) {
 	fightTactics := FightTactics{&loggertest.Logger{}}
 
@@ -66,9 +186,10 @@ func TestGetBestRoundOfSpells(t *testing.T) {
 		champion, err := readMockTestChampion(mockChampion2)
 		assert.Nil(t, err)
 
-		fightTactics.getBestRoundOfSpells(0, champion.Spells, sol, enemyHp, &bestSol)
+		spells := withoutAutoAttack(champion.Spells)
+		fightTactics.getBestRoundOfSpells(0, spells, sol, enemyHp, &bestSol)
 
-		qSpell := champion.Spells[0]
+		qSpell := spells[0]
 		maxRank := qSpell.MaxRank - 1
 
 		spellUsedTimes := math.Ceil(enemyHp / qSpell.Damage[maxRank])
@@ -88,9 +209,10 @@ func TestGetBestRoundOfSpells(t *testing.T) {
 		champion, err := readMockTestChampion(mockChampion3)
 		assert.Nil(t, err)
 
-		fightTactics.getBestRoundOfSpells(0, champion.Spells, sol, enemyHp, &bestSol)
+		spells := withoutAutoAttack(champion.Spells)
+		fightTactics.getBestRoundOfSpells(0, spells, sol, enemyHp, &bestSol)
 
-		usedSpells := []Spell{champion.Spells[0], champion.Spells[1], champion.Spells[2], champion.Spells[3]}
+		usedSpells := []Spell{spells[0], spells[1], spells[2], spells[3]}
 
 		totCastTime := usedSpells[0].Cast + usedSpells[1].Cast + usedSpells[2].Cast + usedSpells[3].Cast
 
@@ -110,9 +232,9 @@ func TestGetBestRoundOfSpells(t *testing.T) {
 		champion, err := readMockTestChampion(mockChampion3)
 		assert.Nil(t, err)
 
-		fightTactics.getBestRoundOfSpells(0, champion.Spells, sol, enemyHp, &bestSol)
+		spells := withoutAutoAttack(champion.Spells)
+		fightTactics.getBestRoundOfSpells(0, spells, sol, enemyHp, &bestSol)
 
-		spells := []Spell{champion.Spells[0], champion.Spells[1], champion.Spells[2], champion.Spells[3]}
 		usedSpells := []Spell{spells[0], spells[2], spells[0], spells[3], spells[0]}
 
 		totCastTime := spells[0].Cast + spells[2].Cast + spells[0].Cast + spells[3].Cast + spells[0].Cast // 0 cooldown time
@@ -297,6 +419,23 @@ func TestGetBenchmark(t *testing.T) {
 	})
 }
 
+func TestSpellTimes(t *testing.T) {
+	t.Run("re-usage spells in a row", func(t *testing.T) {
+		aa := Spell{ID: "aa", Damage: []float64{10}, MaxRank: 1, Cooldown: []float64{0}, Cast: 0.5}
+		w := Spell{ID: "w", Damage: []float64{6, 7, 8, 9, 20}, MaxRank: 5, Cooldown: []float64{5, 4, 3, 2, 2}, Cast: 2.0}
+
+		times := SpellTimes([]Spell{aa, w, w})
+
+		assert.Equal(t, []float64{0.5, 2.5, 6.5}, times) // second W waits for its cooldown first
+		assert.Equal(t, times[2], getBenchmark([]Spell{aa, w, w}))
+	})
+
+	t.Run("no spells", func(t *testing.T) {
+		assert.Empty(t, SpellTimes(nil))
+		assert.Equal(t, 0.0, getBenchmark(nil))
+	})
+}
+
 func TestGetAdditionalTimeIfSpellIsInCooldown(t *testing.T) {
 	t.Run("re-usage spell one time", func(t *testing.T) {
 		usedSpells := []Spell{
@@ -0,0 +1,65 @@
+package lol
+
+import (
+	"fmt"
+	"math"
+)
+
+const teamFightMaxSize = 5
+
+// TeamFightOptions Starting conditions of a team fight
+type TeamFightOptions struct {
+	Distance  float64   // initial distance between the two teams, zero means everyone is already in range of every spell
+	Targeting [2]string // targeting policy of each team (TargetLowestHp, TargetClosest or TargetCarry), lowest hp if missing
+}
+
+// TeamFightSol Outcome of a fight between two teams
+type TeamFightSol struct {
+	Winner   int     // winning team (1 or 2), 0 in case of draw
+	Duration float64 // time (in seconds) at which the team fight ended
+	Teams    [2][]TeamFightChampion
+}
+
+type TeamFightChampion struct {
+	Name         string
+	DeathTime    float64 // time (in seconds) at which the champion died, -1 if it survived
+	DamageDealt  float64
+	CCApplied    float64 // total crowd control (in seconds) applied to the enemies, tenacity included
+	HealthPoints float64 // health points left at the end of the team fight
+}
+
+// TeamFight Team1 vs Team2 (up to 5v5) on the same timeline, where each champion focuses the enemy picked by its team targeting policy
+func (f *FightTactics) TeamFight(team1, team2 []Champion, opts TeamFightOptions) (TeamFightSol, error) {
+	for i, team := range [2][]Champion{team1, team2} {
+		if len(team) == 0 || len(team) > teamFightMaxSize {
+			return TeamFightSol{}, fmt.Errorf("team %d must have between 1 and %d champions, got %d", i+1, teamFightMaxSize, len(team))
+		}
+	}
+	for i, targeting := range opts.Targeting {
+		if targeting != "" && targeting != TargetLowestHp && targeting != TargetClosest && targeting != TargetCarry {
+			return TeamFightSol{}, fmt.Errorf("unknown targeting policy %q for team %d", targeting, i+1)
+		}
+	}
+
+	fight := newCombat(team1, team2, opts.Distance, opts.Targeting)
+	fight.run()
+
+	sol := TeamFightSol{Winner: fight.winner() + 1, Duration: fight.t}
+	for _, c := range fight.fighters {
+		deathTime := c.deathTime
+		if math.IsInf(deathTime, 1) {
+			deathTime = -1
+		}
+		sol.Teams[c.team] = append(sol.Teams[c.team], TeamFightChampion{
+			Name:         c.champion.Name,
+			DeathTime:    deathTime,
+			DamageDealt:  c.damageDealt,
+			CCApplied:    c.ccApplied,
+			HealthPoints: math.Max(c.hp, 0),
+		})
+	}
+
+	f.log.Printf("Team fight ended in %.2fs (winner: team %d)\n", sol.Duration, sol.Winner)
+
+	return sol, nil
+}
@@ -0,0 +1,99 @@
+package lol
+
+import (
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamFight(t *testing.T) {
+	fightTactics := FightTactics{&loggertest.Logger{}}
+
+	aa := Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}}
+
+	t.Run("bigger team wins", func(t *testing.T) {
+		team1 := []Champion{getMockChampion(withName("a"), withHealthPoints(100), withSpells(aa)), getMockChampion(withName("b"), withHealthPoints(100), withSpells(aa))}
+		team2 := []Champion{getMockChampion(withName("c"), withHealthPoints(100), withSpells(aa))}
+
+		sol, err := fightTactics.TeamFight(team1, team2, TeamFightOptions{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, sol.Winner)
+		assert.Equal(t, 4.0, sol.Duration) // 5 rounds of 2 auto attacks
+		assert.Equal(t, -1.0, sol.Teams[0][0].DeathTime)
+		assert.Equal(t, -1.0, sol.Teams[0][1].DeathTime)
+		assert.Equal(t, 4.0, sol.Teams[1][0].DeathTime)
+	})
+
+	t.Run("focus lowest hp", func(t *testing.T) {
+		team1 := []Champion{getMockChampion(withName("a"), withHealthPoints(1000), withSpells(aa))}
+		team2 := []Champion{getMockChampion(withName("tank"), withHealthPoints(100), withSpells(aa)), getMockChampion(withName("squishy"), withHealthPoints(30), withSpells(aa))}
+
+		sol, err := fightTactics.TeamFight(team1, team2, TeamFightOptions{Targeting: [2]string{TargetLowestHp, TargetLowestHp}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2.0, sol.Teams[1][1].DeathTime)
+		assert.Equal(t, 12.0, sol.Teams[1][0].DeathTime)
+	})
+
+	t.Run("focus carry", func(t *testing.T) {
+		nuke := Spell{ID: "r", MaxRank: 1, Damage: []float64{50}, Cooldown: []float64{100}}
+		team1 := []Champion{getMockChampion(withName("a"), withHealthPoints(1000), withSpells(aa))}
+		team2 := []Champion{getMockChampion(withName("support"), withHealthPoints(30), withSpells(aa)), getMockChampion(withName("carry"), withHealthPoints(50), withSpells(aa, nuke))}
+
+		sol, err := fightTactics.TeamFight(team1, team2, TeamFightOptions{Targeting: [2]string{TargetCarry, ""}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 4.0, sol.Teams[1][1].DeathTime)
+		assert.Equal(t, 7.0, sol.Teams[1][0].DeathTime)
+	})
+
+	t.Run("focus closest", func(t *testing.T) {
+		melee := getMockChampion(withName("melee"), withHealthPoints(1000), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}, Range: []float64{100}}))
+		melee.Stats.MoveSpeed = 100
+		team1 := []Champion{getMockChampion(withName("a"), withHealthPoints(1000), withSpells(aa)), melee}
+		team2 := []Champion{getMockChampion(withName("b"), withHealthPoints(1000), withSpells(Spell{ID: "aa", MaxRank: 1, Damage: []float64{10}, Cooldown: []float64{1}, Range: []float64{100}}))}
+
+		sol, err := fightTactics.TeamFight(team1, team2, TeamFightOptions{Distance: 500, Targeting: [2]string{"", TargetClosest}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, sol.Winner)
+		assert.Equal(t, 1000.0, sol.Teams[0][0].HealthPoints) // out of reach, b only hit the melee champion approaching it
+		assert.Less(t, sol.Teams[0][1].HealthPoints, 1000.0)
+	})
+
+	t.Run("area of effect", func(t *testing.T) {
+		aoe := Spell{ID: "r", MaxRank: 1, Damage: []float64{100}, Cooldown: []float64{100}, Targets: 3}
+		team1 := []Champion{getMockChampion(withName("a"), withHealthPoints(1000), withSpells(aoe))}
+		team2 := []Champion{getMockChampion(withName("b"), withHealthPoints(100), withSpells()), getMockChampion(withName("c"), withHealthPoints(100), withSpells()), getMockChampion(withName("d"), withHealthPoints(200), withSpells())}
+
+		sol, err := fightTactics.TeamFight(team1, team2, TeamFightOptions{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0.0, sol.Teams[1][0].DeathTime)
+		assert.Equal(t, 0.0, sol.Teams[1][1].DeathTime)
+		assert.Equal(t, 100.0, sol.Teams[1][2].DeathTime) // survived the first hit, died once the spell was off cooldown
+		assert.Equal(t, 400.0, sol.Teams[0][0].DamageDealt)
+	})
+
+	t.Run("too many champions", func(t *testing.T) {
+		team := []Champion{{}, {}, {}, {}, {}, {}}
+
+		_, err := fightTactics.TeamFight(team, []Champion{{}}, TeamFightOptions{})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("empty team", func(t *testing.T) {
+		_, err := fightTactics.TeamFight([]Champion{{}}, nil, TeamFightOptions{})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("unknown targeting", func(t *testing.T) {
+		_, err := fightTactics.TeamFight([]Champion{{}}, []Champion{{}}, TeamFightOptions{Targeting: [2]string{"random", ""}})
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,240 @@
+package lol
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AutoAttackID Id of the auto attack spell every champion is expected to have
+const AutoAttackID = "aa"
+
+// Damage types of a spell
+const (
+	DamagePhysical = "physical"
+	DamageMagic    = "magic"
+	DamageTrue     = "true"
+)
+
+// ErrInvalidChampion Champion data too inconsistent to be loaded or fought with, the details being in the wrapping ValidationError
+var ErrInvalidChampion = errors.New("invalid champion")
+
+// ValidationIssue Problem found in a champion, at the field named after its YAML key (e.g. spells[JhinQ].damage), empty if about the whole file
+type ValidationIssue struct {
+	Field   string `json:"field"`
+	Spell   string `json:"spell,omitempty"` // id of the spell the field belongs to, if any
+	Message string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	if i.Field == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Field, i.Message)
+}
+
+// ValidationError Issues making a champion invalid
+type ValidationError struct {
+	Champion string // champion name
+	File     string // champion file, empty if the champion was not read from a file
+	Issues   []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	issues := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		issues[i] = issue.String()
+	}
+	if e.File == "" {
+		return fmt.Sprintf("invalid champion %s: %s", e.Champion, strings.Join(issues, "; "))
+	}
+	return fmt.Sprintf("invalid champion %s (%s): %s", e.Champion, e.File, strings.Join(issues, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidChampion
+}
+
+// ChampionFileReader Repository able to return the raw content of a champion file, so that it can be strictly checked
+type ChampionFileReader interface {
+	ReadChampionFile(name string) ([]byte, error)
+}
+
+// CheckChampionFile Decode the champion file (of the current schema version) strictly, reporting unknown (e.g. misspelled) fields and values of the wrong type
+func CheckChampionFile(data []byte) []ValidationIssue {
+	err := yaml.UnmarshalStrict(data, &Champion{})
+	if err == nil {
+		return nil
+	}
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		issues := make([]ValidationIssue, 0, len(typeErr.Errors))
+		for _, e := range typeErr.Errors {
+			issues = append(issues, ValidationIssue{Message: e})
+		}
+		return issues
+	}
+	return []ValidationIssue{{Message: err.Error()}}
+}
+
+// decodeChampion Champion of the file (migrated to the current schema version), along with the issues of its strict decoding (e.g.
+// unknown fields), then of its validation. Unlike the strict decoding, the validation can be skipped (e.g. when an override still has to be applied)
+func decodeChampion(data []byte, validate bool) (Champion, []ValidationIssue) {
+	data, err := migrateChampionFile(data)
+	if err != nil {
+		return Champion{}, []ValidationIssue{{Message: err.Error()}}
+	}
+	issues := CheckChampionFile(data)
+
+	var champion Champion
+	err = yaml.Unmarshal(data, &champion)
+	var typeErr *yaml.TypeError
+	if err != nil && !errors.As(err, &typeErr) {
+		return Champion{}, issues // not even YAML, nothing more to tell
+	}
+	champion.Version = CurrentSchemaVersion
+
+	if validate {
+		issues = append(issues, ValidateChampion(champion)...)
+	}
+	return champion, issues
+}
+
+// loadChampion Strictly decoded and valid champion of the given file, of the given format
+func loadChampion(name, file, format string, data []byte) (Champion, error) {
+	data, err := championFileToYAML(data, format)
+	if err != nil {
+		return Champion{}, &ValidationError{Champion: name, File: file, Issues: []ValidationIssue{{Message: err.Error()}}}
+	}
+
+	champion, issues := decodeChampion(data, true)
+	if len(issues) > 0 {
+		return Champion{}, &ValidationError{Champion: name, File: file, Issues: issues}
+	}
+	return champion, nil
+}
+
+// ValidateChampion Check the champion data is consistent enough to fight, e.g. every spell having as many damage and cooldown values as ranks
+func ValidateChampion(champion Champion) []ValidationIssue {
+	var issues []ValidationIssue
+	addIssue := func(field, format string, args ...interface{}) {
+		issues = append(issues, ValidationIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if champion.ID == "" {
+		addIssue("id", "missing")
+	}
+	if champion.Name == "" {
+		addIssue("name", "missing")
+	}
+
+	if champion.Stats.HealthPoints <= 0 {
+		addIssue("stats.health_points", "must be positive, got %v", champion.Stats.HealthPoints)
+	}
+	for _, stat := range []struct {
+		field string
+		value float64
+	}{
+		{"stats.attack_damage", champion.Stats.AttackDamage},
+		{"stats.attack_speed", champion.Stats.AttackSpeed},
+		{"stats.attack_range", champion.Stats.AttackRange},
+		{"stats.move_speed", champion.Stats.MoveSpeed},
+	} {
+		if stat.value < 0 {
+			addIssue(stat.field, "must not be negative, got %v", stat.value)
+		}
+	}
+	if champion.Stats.Tenacity < 0 || champion.Stats.Tenacity > 1 {
+		addIssue("stats.tenacity", "must be between 0 and 1, got %v", champion.Stats.Tenacity)
+	}
+
+	hasAutoAttack := false
+	spellIDs := map[string]bool{}
+	for i, spell := range champion.Spells {
+		field := fmt.Sprintf("spells[%d]", i)
+		if spell.ID == "" {
+			addIssue(field+".id", "missing")
+		} else {
+			field = fmt.Sprintf("spells[%s]", spell.ID)
+			if spellIDs[spell.ID] {
+				issues = append(issues, ValidationIssue{Field: field + ".id", Spell: spell.ID, Message: "duplicate spell id"})
+			}
+			spellIDs[spell.ID] = true
+		}
+		if spell.ID == AutoAttackID {
+			hasAutoAttack = true
+		}
+		issues = append(issues, validateSpell(field, spell)...)
+	}
+	if !hasAutoAttack {
+		addIssue("spells", "missing %s (auto attack) spell", AutoAttackID)
+	}
+
+	return issues
+}
+
+func validateSpell(field string, spell Spell) []ValidationIssue {
+	var issues []ValidationIssue
+	addIssue := func(subfield, format string, args ...interface{}) {
+		issues = append(issues, ValidationIssue{Field: field + "." + subfield, Spell: spell.ID, Message: fmt.Sprintf(format, args...)})
+	}
+	checkRanks := func(subfield string, values []float64, optional bool) {
+		if optional && values == nil {
+			return
+		}
+		if spell.MaxRank >= 1 && len(values) < spell.MaxRank {
+			addIssue(subfield, "has %d values, fewer than max_rank (%d)", len(values), spell.MaxRank)
+		}
+		for _, value := range values {
+			if value < 0 {
+				addIssue(subfield, "must not be negative, got %v", value)
+				break
+			}
+		}
+	}
+
+	if spell.MaxRank < 1 {
+		addIssue("max_rank", "must be at least 1, got %d", spell.MaxRank)
+	}
+	checkRanks("damage", spell.Damage, false)
+	checkRanks("cooldown", spell.Cooldown, false)
+	checkRanks("range", spell.Range, true)
+	stats := make([]string, 0, len(spell.Ratios))
+	for stat := range spell.Ratios {
+		stats = append(stats, stat)
+	}
+	sort.Strings(stats)
+	for _, stat := range stats {
+		checkRanks(fmt.Sprintf("ratios.%s", stat), spell.Ratios[stat], false)
+	}
+	for _, cc := range spell.CC {
+		switch cc.Type {
+		case CCStun, CCKnockUp, CCSilence, CCRoot:
+		default:
+			addIssue(fmt.Sprintf("cc[%s].type", cc.Type), "unknown crowd control type, expected %s, %s, %s or %s", CCStun, CCKnockUp, CCSilence, CCRoot)
+		}
+		checkRanks(fmt.Sprintf("cc[%s].duration", cc.Type), cc.Duration, false)
+	}
+
+	if spell.Cast < 0 {
+		addIssue("cast", "must not be negative, got %v", spell.Cast)
+	}
+	if spell.Dash < 0 {
+		addIssue("dash", "must not be negative, got %v", spell.Dash)
+	}
+	if spell.Targets < 0 {
+		addIssue("targets", "must not be negative, got %d", spell.Targets)
+	}
+	switch spell.DamageType {
+	case "", DamagePhysical, DamageMagic, DamageTrue:
+	default:
+		addIssue("damage_type", "unknown damage type %q, expected %s, %s or %s", spell.DamageType, DamagePhysical, DamageMagic, DamageTrue)
+	}
+	if spell.Confidence < 0 || spell.Confidence > 1 {
+		addIssue("confidence", "must be between 0 and 1, got %v", spell.Confidence)
+	}
+
+	return issues
+}
@@ -0,0 +1,119 @@
+package lol
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestValidChampion() Champion {
+	return Champion{
+		ID:    "Jhin",
+		Name:  "Jhin",
+		Stats: Stats{HealthPoints: 655, AttackDamage: 59, AttackSpeed: 0.625},
+		Spells: []Spell{
+			{ID: "aa", MaxRank: 1, Damage: []float64{59}, Cooldown: []float64{1.6}},
+			{
+				ID:       "JhinW",
+				MaxRank:  5,
+				Damage:   []float64{60, 95, 130, 165, 200},
+				Ratios:   map[string][]float64{"TotalADRatio": {0.5, 0.5, 0.5, 0.5, 0.5}},
+				Cooldown: []float64{12, 12, 12, 12, 12},
+				CC:       []CrowdControl{{Type: CCRoot, Duration: []float64{1.25, 1.5, 1.75, 2, 2.25}}},
+			},
+		},
+	}
+}
+
+func TestCheckChampionFile(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Nil(t, CheckChampionFile([]byte("id: Jhin\nname: Jhin\nstats:\n  health_points: 655\n")))
+	})
+
+	t.Run("unknown fields and wrong types", func(t *testing.T) {
+		issues := CheckChampionFile([]byte("id: Jhin\nstats:\n  helth_points: 655\nspells:\n- id: aa\n  max_rank: one\n"))
+
+		assert.Len(t, issues, 2)
+		assert.Contains(t, issues[0].Message, "helth_points")
+		assert.Contains(t, issues[1].Message, "one")
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		issues := CheckChampionFile([]byte("id: [Jhin"))
+
+		assert.Len(t, issues, 1)
+	})
+}
+
+func TestValidateChampion(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, ValidateChampion(newTestValidChampion()))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		champion := newTestValidChampion()
+		champion.Name = ""
+		champion.Stats.HealthPoints = 0
+		champion.Stats.MoveSpeed = -1
+		champion.Spells[0].ID = "JhinW" // duplicate, and no more auto attack
+		champion.Spells[1].Damage = []float64{60, 95, 130}
+		champion.Spells[1].Ratios["TotalADRatio"][1] = -0.5
+		champion.Spells[1].CC = []CrowdControl{{Type: "fear", Duration: []float64{1, 1, 1, 1, 1}}}
+		champion.Spells[1].DamageType = "holy"
+		champion.Spells = append(champion.Spells, Spell{ID: "JhinE", Damage: []float64{20}, Cooldown: []float64{2}, Confidence: 2})
+
+		issues := ValidateChampion(champion)
+
+		assert.Equal(t, []ValidationIssue{
+			{Field: "name", Message: "missing"},
+			{Field: "stats.health_points", Message: "must be positive, got 0"},
+			{Field: "stats.move_speed", Message: "must not be negative, got -1"},
+			{Field: "spells[JhinW].id", Spell: "JhinW", Message: "duplicate spell id"},
+			{Field: "spells[JhinW].damage", Spell: "JhinW", Message: "has 3 values, fewer than max_rank (5)"},
+			{Field: "spells[JhinW].ratios.TotalADRatio", Spell: "JhinW", Message: "must not be negative, got -0.5"},
+			{Field: "spells[JhinW].cc[fear].type", Spell: "JhinW", Message: "unknown crowd control type, expected stun, knockup, silence or root"},
+			{Field: "spells[JhinW].damage_type", Spell: "JhinW", Message: `unknown damage type "holy", expected physical, magic or true`},
+			{Field: "spells[JhinE].max_rank", Spell: "JhinE", Message: "must be at least 1, got 0"},
+			{Field: "spells[JhinE].confidence", Spell: "JhinE", Message: "must be between 0 and 1, got 2"},
+			{Field: "spells", Message: "missing aa (auto attack) spell"},
+		}, issues)
+	})
+}
+
+func TestChampionSchema(t *testing.T) {
+	data, err := os.ReadFile("../../schemas/champion.schema.json")
+	assert.Nil(t, err)
+
+	var schema struct {
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Definitions map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"definitions"`
+	}
+	err = json.Unmarshal(data, &schema)
+	assert.Nil(t, err)
+
+	// Every YAML field must be described by the schema, so that it does not fall behind the Go structs
+	for definition, typ := range map[string]reflect.Type{
+		"":              reflect.TypeOf(Champion{}),
+		"passive":       reflect.TypeOf(Passive{}),
+		"stats":         reflect.TypeOf(Stats{}),
+		"spell":         reflect.TypeOf(Spell{}),
+		"crowd_control": reflect.TypeOf(CrowdControl{}),
+		"localization":  reflect.TypeOf(Localization{}),
+	} {
+		properties := schema.Properties
+		if definition != "" {
+			properties = schema.Definitions[definition].Properties
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			key := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+			assert.Contains(t, properties, key, "%s field missing from definition %q", key, definition)
+		}
+		assert.Len(t, properties, typ.NumField(), "definition %q has fields %s does not", definition, typ.Name())
+	}
+}
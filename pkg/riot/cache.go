@@ -0,0 +1,161 @@
+//go:generate mockery --case underscore --dir . --name Cache --output ./mocks
+
+package riot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+)
+
+const (
+	cacheFileExtension = ".json"
+	cacheUnversioned   = "unversioned" // patch of the responses not bound to any (e.g. the list of versions)
+)
+
+// Cache Storage of Data Dragon responses, keyed by patch and URL
+type Cache interface {
+	Get(patch, url string) (entry CacheEntry, ok bool)
+	Put(patch, url string, entry CacheEntry) error
+	Stats() (CacheStats, error)
+	Clear(patches ...string) error
+}
+
+// CacheEntry Cached response, along with what is needed to revalidate it (i.e. ETag and Last-Modified headers)
+type CacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"` // last time the response was fetched or revalidated
+	Body         []byte    `json:"body"`
+}
+
+type CacheStats struct {
+	Dir     string
+	Entries int
+	Bytes   int64
+	Patches map[string]int // number of entries by patch
+}
+
+// DiskCache Cache keeping each response in its own file, inside a directory per patch
+type DiskCache struct {
+	dir string
+}
+
+func NewDiskCache(dir string) Cache {
+	return &DiskCache{dir: dir}
+}
+
+func (c *DiskCache) Get(patch, url string) (CacheEntry, bool) {
+	data, err := os.ReadFile(c.entryPath(patch, url))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *DiskCache) Put(patch, url string, entry CacheEntry) error {
+	entry.URL = url
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	entryPath := c.entryPath(patch, url)
+	err = os.MkdirAll(filepath.Dir(entryPath), 0700)
+	if err != nil {
+		return err
+	}
+
+	// Write then rename, so that concurrent readers never see a partially written entry
+	tmp, err := os.CreateTemp(filepath.Dir(entryPath), "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), entryPath)
+}
+
+func (c *DiskCache) Stats() (CacheStats, error) {
+	stats := CacheStats{Dir: c.dir, Patches: map[string]int{}}
+
+	patchDirs, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return CacheStats{}, err
+	}
+
+	for _, patchDir := range patchDirs {
+		if !patchDir.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(c.dir, patchDir.Name()))
+		if err != nil {
+			return CacheStats{}, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != cacheFileExtension {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				return CacheStats{}, err
+			}
+			stats.Entries++
+			stats.Bytes += info.Size()
+			stats.Patches[patchDir.Name()]++
+		}
+	}
+
+	return stats, nil
+}
+
+// Clear Remove the cached responses of the given patches, or all of them if none is given
+func (c *DiskCache) Clear(patches ...string) error {
+	if len(patches) == 0 {
+		return os.RemoveAll(c.dir)
+	}
+	for _, patch := range patches {
+		// Patch directories only, anything else (e.g. "..") could remove files outside of the cache
+		if patch != cacheUnversioned && !lol.IsPatch(patch) {
+			return fmt.Errorf("invalid patch %q, expected a version (e.g. 14.20.1) or %s", patch, cacheUnversioned)
+		}
+		err := os.RemoveAll(filepath.Join(c.dir, patch))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DiskCache) entryPath(patch, url string) string {
+	if patch == "" {
+		patch = cacheUnversioned
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, patch, hex.EncodeToString(sum[:])+cacheFileExtension)
+}
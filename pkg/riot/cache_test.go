@@ -0,0 +1,144 @@
+package riot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskCache(t *testing.T) {
+	t.Run("put and get", func(t *testing.T) {
+		cache := NewDiskCache(t.TempDir())
+		entry := CacheEntry{ETag: `"abc"`, FetchedAt: time.Now().UTC().Truncate(time.Second), Body: []byte(`{"data": {}}`)}
+
+		err := cache.Put("13.1.1", "https://example.com/champion.json", entry)
+		assert.Nil(t, err)
+
+		cached, ok := cache.Get("13.1.1", "https://example.com/champion.json")
+		assert.True(t, ok)
+		entry.URL = "https://example.com/champion.json"
+		assert.Equal(t, entry, cached)
+
+		_, ok = cache.Get("12.3.1", "https://example.com/champion.json")
+		assert.False(t, ok)
+	})
+
+	t.Run("stats and clear", func(t *testing.T) {
+		cache := NewDiskCache(t.TempDir())
+		assert.Nil(t, cache.Put("", "https://example.com/versions.json", CacheEntry{Body: []byte(`[]`)}))
+		assert.Nil(t, cache.Put("13.1.1", "https://example.com/a.json", CacheEntry{Body: []byte(`{}`)}))
+		assert.Nil(t, cache.Put("13.1.1", "https://example.com/b.json", CacheEntry{Body: []byte(`{}`)}))
+
+		stats, err := cache.Stats()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, stats.Entries)
+		assert.Greater(t, stats.Bytes, int64(0))
+		assert.Equal(t, map[string]int{cacheUnversioned: 1, "13.1.1": 2}, stats.Patches)
+
+		err = cache.Clear("13.1.1")
+		assert.Nil(t, err)
+		stats, err = cache.Stats()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, stats.Entries)
+
+		err = cache.Clear()
+		assert.Nil(t, err)
+		stats, err = cache.Stats()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, stats.Entries)
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		err := NewDiskCache(t.TempDir()).Clear("../13.1.1")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("current and parent directories", func(t *testing.T) {
+		dir := t.TempDir()
+		cache := NewDiskCache(filepath.Join(dir, "cache"))
+		assert.Nil(t, cache.Put("13.1.1", "https://example.com/a.json", CacheEntry{Body: []byte(`{}`)}))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("keep"), 0600))
+
+		for _, patch := range []string{".", ".."} {
+			err := cache.Clear(patch)
+			assert.NotNil(t, err, patch)
+		}
+
+		stats, err := cache.Stats()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, stats.Entries)
+		assert.FileExists(t, filepath.Join(dir, "other.txt"))
+	})
+}
+
+// newTestCachedDataDragon Fake Data Dragon answering 304 whenever the ETag matches, counting the requests it gets
+func newTestCachedDataDragon(t *testing.T, requests *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"data": {"Jhin": {"id": "Jhin", "name": "Jhin"}}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchCache(t *testing.T) {
+	t.Run("fresh", func(t *testing.T) {
+		var requests int
+		srv := newTestCachedDataDragon(t, &requests)
+		client := newTestClient(srv, "13.1.1")
+		client.cache, client.cacheTTL = NewDiskCache(t.TempDir()), time.Hour
+
+		for i := 0; i < 3; i++ {
+			champion, err := client.getChampion(context.Background(), "Jhin")
+			assert.Nil(t, err)
+			assert.Equal(t, "Jhin", champion.ID)
+		}
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("revalidated", func(t *testing.T) {
+		var requests int
+		srv := newTestCachedDataDragon(t, &requests)
+		client := newTestClient(srv, "13.1.1")
+		client.cache = NewDiskCache(t.TempDir()) // no TTL, always revalidate
+
+		for i := 0; i < 2; i++ {
+			champion, err := client.getChampion(context.Background(), "Jhin")
+			assert.Nil(t, err)
+			assert.Equal(t, "Jhin", champion.ID)
+		}
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		var requests int
+		srv := newTestCachedDataDragon(t, &requests)
+		cache := NewDiskCache(t.TempDir())
+		client := newTestClient(srv, "13.1.1")
+		client.cache = cache
+
+		_, err := client.getChampion(context.Background(), "Jhin")
+		assert.Nil(t, err)
+
+		srv.Close()
+		offlineClient := &Concrete{log: &loggertest.Logger{}, hc: &http.Client{}, baseURL: srv.URL, locale: dDragonDefaultLocale, cache: cache, patch: "13.1.1"}
+
+		champion, err := offlineClient.getChampion(context.Background(), "Jhin")
+		assert.Nil(t, err)
+		assert.Equal(t, "Jhin", champion.ID)
+	})
+}
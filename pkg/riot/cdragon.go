@@ -0,0 +1,176 @@
+//go:generate mockery --case underscore --dir . --name CDragonClient --output ./mocks
+
+package riot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger"
+)
+
+// Docs: https://www.communitydragon.org/documentation/assets
+const (
+	cDragonBaseURL      = "https://raw.communitydragon.org"
+	cDragonChampionPath = "/%s/game/data/characters/%s/%s.bin.json"
+	cDragonMirrorScheme = "file"
+
+	cDragonSpellObject = "SpellObject"
+)
+
+// CDragonClient Source of the spell numbers of the game files, as extracted by CommunityDragon, far more accurate than the Data Dragon ones
+type CDragonClient interface {
+	GetChampionSpells(ctx context.Context, patch, championID string) (map[string]CDragonSpell, error)
+}
+
+// CDragonSpell Spell numbers of a champion bin file, where every slice is by spell rank (starting from rank 1)
+type CDragonSpell struct {
+	ID       string               // script name, same as the Data Dragon spell id (e.g. JhinQ)
+	Damage   []float64            // base damage, before any ratio is applied
+	Ratios   map[string][]float64 // damage scaling, by data value name (e.g. TotalADRatio)
+	Cooldown []float64
+	Cast     float64 // cast time, in seconds
+	Range    []float64
+}
+
+type CDragon struct {
+	fetcher *Concrete // plain HTTP transport, with the retry, rate limit and cache options of Data Dragon but a rate limiter of its own (being another host)
+	baseURL string
+}
+
+// NewCDragonClient CommunityDragon client reading from source, either its URL or a directory mirroring it
+// (e.g. tests/cdragon, holding 13.1/game/data/characters/jhin/jhin.bin.json), https://raw.communitydragon.org if empty
+func NewCDragonClient(log logger.Logger, hc *http.Client, source string, opts Options) CDragonClient {
+	baseURL := strings.TrimSuffix(source, "/")
+	if baseURL == "" {
+		baseURL = cDragonBaseURL
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		transport := &http.Transport{}
+		transport.RegisterProtocol(cDragonMirrorScheme, http.NewFileTransport(http.Dir(baseURL)))
+		mirrorHC := *hc
+		mirrorHC.Transport = transport
+		hc, baseURL = &mirrorHC, cDragonMirrorScheme+"://"
+	}
+
+	return &CDragon{fetcher: NewClient(log, hc, opts).(*Concrete), baseURL: baseURL}
+}
+
+// cDragonBinEntry Any entry of a bin file, only spell objects being of interest
+type cDragonBinEntry struct {
+	Type       string           `json:"__type"`
+	ScriptName string           `json:"mScriptName"`
+	Spell      *cDragonBinSpell `json:"mSpell"`
+}
+
+type cDragonBinSpell struct {
+	CastTime     float64   `json:"mCastTime"`
+	CooldownTime []float64 `json:"cooldownTime"`
+	CastRange    []float64 `json:"castRange"`
+	DataValues   []struct {
+		Name   string    `json:"mName"`
+		Values []float64 `json:"mValues"`
+	} `json:"mDataValues"`
+}
+
+// GetChampionSpells Spell numbers of the given champion (Data Dragon id, e.g. Jhin) and patch (e.g. 13.1.1), by spell id
+func (c *CDragon) GetChampionSpells(ctx context.Context, patch, championID string) (map[string]CDragonSpell, error) {
+	cDragonID := strings.ToLower(championID)
+	url := c.baseURL + fmt.Sprintf(cDragonChampionPath, cDragonPatch(patch), cDragonID, cDragonID)
+
+	var bin map[string]json.RawMessage
+	err := c.fetcher.httpGet(ctx, patch, url, &bin)
+	if err != nil {
+		return nil, fmt.Errorf("could not get champion %s from communitydragon: %w", championID, err)
+	}
+
+	spells := parseCDragonBin(bin)
+	if len(spells) == 0 {
+		return nil, fmt.Errorf("no spell found for champion %s in communitydragon", championID)
+	}
+
+	return spells, nil
+}
+
+// cDragonPatch CommunityDragon directory of a Data Dragon version, i.e. its major and minor version only (e.g. 13.1.1 is 13.1)
+func cDragonPatch(patch string) string {
+	parts := strings.Split(patch, ".")
+	if len(parts) < 2 {
+		return patch
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// parseCDragonBin Spells of a champion bin file, by script name
+func parseCDragonBin(bin map[string]json.RawMessage) map[string]CDragonSpell {
+	spells := map[string]CDragonSpell{}
+	for _, raw := range bin {
+		var entry cDragonBinEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue // not an object, nothing to extract from it
+		}
+		if entry.Type != cDragonSpellObject || entry.Spell == nil || entry.ScriptName == "" {
+			continue
+		}
+		spells[entry.ScriptName] = mapCDragonSpell(entry.ScriptName, *entry.Spell)
+	}
+	return spells
+}
+
+func mapCDragonSpell(id string, binSpell cDragonBinSpell) CDragonSpell {
+	spell := CDragonSpell{
+		ID:       id,
+		Cooldown: binSpell.CooldownTime,
+		Cast:     binSpell.CastTime,
+		Range:    binSpell.CastRange,
+	}
+
+	// Data values hold a leading rank 0 value (unlike cooldowns and ranges), which is dropped
+	dataValues := map[string][]float64{}
+	names := make([]string, 0, len(binSpell.DataValues))
+	for _, dataValue := range binSpell.DataValues {
+		if len(dataValue.Values) < 2 {
+			continue
+		}
+		dataValues[dataValue.Name] = dataValue.Values[1:]
+		names = append(names, dataValue.Name)
+	}
+	sort.Strings(names)
+
+	if damageName := cDragonDamageName(names); damageName != "" {
+		spell.Damage = dataValues[damageName]
+	}
+	for _, name := range names {
+		if strings.HasSuffix(strings.ToLower(name), "ratio") {
+			if spell.Ratios == nil {
+				spell.Ratios = map[string][]float64{}
+			}
+			spell.Ratios[name] = dataValues[name]
+		}
+	}
+
+	return spell
+}
+
+// cDragonDamageName Data value holding the base damage: each spell names it its own way, so the well known names are looked for first,
+// then anything ending with "Damage" (e.g. QDamage) that is not a percentage
+func cDragonDamageName(names []string) string {
+	for _, wellKnown := range []string{"BaseDamage", "Damage", "TotalDamage"} {
+		for _, name := range names {
+			if strings.EqualFold(name, wellKnown) {
+				return name
+			}
+		}
+	}
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		if strings.HasSuffix(lower, "damage") && !strings.Contains(lower, "percent") {
+			return name
+		}
+	}
+	return ""
+}
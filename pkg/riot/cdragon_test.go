@@ -0,0 +1,68 @@
+package riot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCDragonMirror = "../../tests/cdragon"
+
+func TestGetChampionSpells(t *testing.T) {
+	t.Run("mirror", func(t *testing.T) {
+		client := NewCDragonClient(&loggertest.Logger{}, &http.Client{}, testCDragonMirror, Options{Retries: -1, RateLimit: -1})
+
+		spells, err := client.GetChampionSpells(context.Background(), "13.1.1", "Jhin")
+
+		assert.Nil(t, err)
+		assert.Len(t, spells, 5) // JhinQMisBounce included, even if no Data Dragon spell matches it
+		assert.Equal(t, CDragonSpell{
+			ID:       "JhinQ",
+			Damage:   []float64{45, 70, 95, 120, 145, 170},
+			Ratios:   map[string][]float64{"APRatio": {0.6, 0.6, 0.6, 0.6, 0.6, 0.6}, "TotalADRatio": {0.35, 0.425, 0.5, 0.575, 0.65, 0.725}},
+			Cooldown: []float64{9, 7.5, 6, 4.5, 3, 3, 3},
+			Cast:     0.25,
+			Range:    []float64{550, 550, 550, 550, 550, 550, 550},
+		}, spells["JhinQ"])
+		assert.Equal(t, []float64{60, 95, 130, 165, 200, 235}, spells["JhinW"].Damage)
+		assert.Equal(t, []float64{20, 80, 140, 200, 260, 320}, spells["JhinE"].Damage)
+		assert.Equal(t, []float64{64, 154, 244, 334, 424, 514}, spells["JhinR"].Damage)
+		assert.Equal(t, 1.0, spells["JhinR"].Cast)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		fixture, err := os.ReadFile(testCDragonMirror + "/13.1/game/data/characters/jhin/jhin.bin.json")
+		assert.Nil(t, err)
+		mux := http.NewServeMux()
+		mux.HandleFunc(fmt.Sprintf(cDragonChampionPath, "13.1", "jhin", "jhin"), func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(fixture)
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		spells, err := NewCDragonClient(&loggertest.Logger{}, srv.Client(), srv.URL, Options{Retries: -1, RateLimit: -1}).GetChampionSpells(context.Background(), "13.1.1", "Jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0.75, spells["JhinW"].Cast)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := NewCDragonClient(&loggertest.Logger{}, &http.Client{}, testCDragonMirror, Options{Retries: -1, RateLimit: -1})
+
+		_, err := client.GetChampionSpells(context.Background(), "13.1.1", "Teemo")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCDragonPatch(t *testing.T) {
+	assert.Equal(t, "13.1", cDragonPatch("13.1.1"))
+	assert.Equal(t, "14.20", cDragonPatch("14.20"))
+	assert.Equal(t, "latest", cDragonPatch("latest"))
+}
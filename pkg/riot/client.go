@@ -3,182 +3,478 @@
 package riot
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger"
-	"github.com/KnutZuidema/golio"
-	"github.com/KnutZuidema/golio/api"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
 	"github.com/KnutZuidema/golio/datadragon"
-	"github.com/sirupsen/logrus"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 // Docs: https://developer.riotgames.com/docs/lol#data-dragon_champions
 const (
-	dDragonLolAllChampionsURL = "https://ddragon.leagueoflegends.com/cdn/12.3.1/data/en_US/champion.json"
-)
-
-var wg sync.WaitGroup
+	dDragonBaseURL          = "https://ddragon.leagueoflegends.com"
+	dDragonVersionsPath     = "/api/versions.json"
+	dDragonAllChampionsPath = "/cdn/%s/data/%s/champion.json"
+	dDragonChampionPath     = "/cdn/%s/data/%s/champion/%s.json"
+	dDragonDefaultLocale    = "en_US"
 
-const (
-	wgN = 30
+	defaultConcurrency = 30
 )
 
 type Client interface {
-	GetAllLoLChampions() ([]datadragon.ChampionDataExtended, error)
-	GetLoLChampion(championName string) (datadragon.ChampionDataExtended, error)
+	GetPatch(ctx context.Context) (string, error)
+	GetAllLoLChampions(ctx context.Context) ([]datadragon.ChampionDataExtended, error)
+	GetLoLChampion(ctx context.Context, championName string) (datadragon.ChampionDataExtended, error)
+}
+
+// ChampionError Failure to fetch a single champion
+type ChampionError struct {
+	ChampionID string
+	Err        error
+}
+
+func (e ChampionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.ChampionID, e.Err)
+}
+
+func (e ChampionError) Unwrap() error {
+	return e.Err
+}
+
+// DownloadError Report of the champions GetAllLoLChampions could not fetch, returned along with all the ones it could
+type DownloadError struct {
+	Total  int // number of champions of the patch
+	Failed []ChampionError
+}
+
+func (e *DownloadError) Error() string {
+	report := fmt.Sprintf("could not fetch %d of %d champions", len(e.Failed), e.Total)
+	for _, failed := range e.Failed {
+		report += "\n  " + failed.Error()
+	}
+	return report
+}
+
+// Options Data Dragon client settings, where zero values fall back on the defaults
+type Options struct {
+	Patch  string // data dragon version every champion is fetched from (e.g. 13.1.1, or 13.1 for its latest one), the latest one if empty
+	Locale string // language of the champion names and descriptions (e.g. it_IT), en_US if empty
+
+	Cache    Cache         // responses are not cached if nil
+	CacheTTL time.Duration // how long a cached response is used as is, before revalidating it with Data Dragon
+
+	Retries    int           // how many times a request is retried when rate limited (429), failing (5xx) or timing out, none if negative
+	RetryDelay time.Duration // delay before the first retry, doubled at each of the following ones
+	RateLimit  float64       // maximum number of requests per second, shared by all the requests of the client, unlimited if negative
+	RateBurst  int           // number of requests that can be made at once before the rate limit kicks in
+
+	Concurrency int // maximum number of champions fetched at once by GetAllLoLChampions
 }
 
 type Concrete struct {
-	log    logger.Logger
-	hc     *http.Client
-	riotDD *golio.Client
+	log      logger.Logger
+	hc       *http.Client
+	baseURL  string
+	locale   string
+	cache    Cache
+	cacheTTL time.Duration
+
+	retries    int
+	retryDelay time.Duration
+	limiter    *rateLimiter        // requests are not rate limited if nil
+	sleep      func(time.Duration) // time.Sleep if nil
+
+	concurrency int // champions downloaded at once, always positive (defaultConcurrency if not set)
+
+	patchMu       sync.Mutex
+	patch         string // version requests are made against, as given until resolved (see GetPatch)
+	patchResolved bool
 }
 
-func NewClient(log logger.Logger, hc *http.Client, apiKey, region string) Client {
-	client := golio.NewClient(apiKey,
-		golio.WithRegion(api.Region(region)),
-		golio.WithLogger(logrus.New().WithField("riot", region)),
-	)
-	return &Concrete{log: log, hc: hc, riotDD: client}
+func NewClient(log logger.Logger, hc *http.Client, opts Options) Client {
+	locale := opts.Locale
+	if locale == "" {
+		locale = dDragonDefaultLocale
+	}
+
+	retries := opts.Retries
+	if retries == 0 {
+		retries = defaultRetries
+	}
+	retryDelay := opts.RetryDelay
+	if retryDelay == 0 {
+		retryDelay = defaultRetryDelay
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var limiter *rateLimiter
+	if opts.RateLimit >= 0 {
+		rateLimit, rateBurst := opts.RateLimit, opts.RateBurst
+		if rateLimit == 0 {
+			rateLimit = defaultRateLimit
+		}
+		if rateBurst <= 0 {
+			rateBurst = defaultRateBurst
+		}
+		limiter = newRateLimiter(rateLimit, rateBurst)
+	}
+
+	return &Concrete{
+		log:         log,
+		hc:          hc,
+		baseURL:     dDragonBaseURL,
+		locale:      locale,
+		cache:       opts.Cache,
+		cacheTTL:    opts.CacheTTL,
+		retries:     retries,
+		retryDelay:  retryDelay,
+		limiter:     limiter,
+		concurrency: concurrency,
+		patch:       opts.Patch,
+	}
 }
 
 type dataDragonLoLAllChampionsResponse struct {
-	Format  string                 `json:"format"`
-	Version string                 `json:"version"`
-	Data    map[string]interface{} `json:"data"`
+	Format  string                               `json:"format"`
+	Version string                               `json:"version"`
+	Data    map[string]dataDragonChampionSummary `json:"data"`
 }
 
-func (c *Concrete) GetAllLoLChampions() ([]datadragon.ChampionDataExtended, error) {
-	var ddAllChampionsResp dataDragonLoLAllChampionsResponse
-	err := c.httpGet(dDragonLolAllChampionsURL, &ddAllChampionsResp)
+// dataDragonChampionSummary Champion as listed by champion.json, by its id
+type dataDragonChampionSummary struct {
+	Name string `json:"name"`
+}
+
+type dataDragonLoLChampionResponse struct {
+	Format  string                                     `json:"format"`
+	Version string                                     `json:"version"`
+	Data    map[string]datadragon.ChampionDataExtended `json:"data"`
+}
+
+// GetPatch Data Dragon version every request is made against, resolved on first use: the given one if it is a full version (e.g. 13.1.1),
+// otherwise the latest one of versions.json it is a prefix of (e.g. 13.1), or the latest one of all if none was given
+func (c *Concrete) GetPatch(ctx context.Context) (string, error) {
+	c.patchMu.Lock()
+	defer c.patchMu.Unlock()
+
+	if c.patchResolved || isFullVersion(c.patch) {
+		c.patchResolved = true
+		return c.patch, nil
+	}
+	if c.patch != "" && !lol.IsPatch(c.patch) {
+		return "", fmt.Errorf("invalid data dragon version %q, expected a version (e.g. 13.1.1) or a prefix of one (e.g. 13.1)", c.patch)
+	}
+
+	var versions []string
+	err := c.httpGet(ctx, "", c.baseURL+dDragonVersionsPath, &versions)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve data dragon version: %w", err)
+	}
+
+	// versions.json also lists some legacy entries (e.g. lolpatch_7.20), which are no version requests can be made against
+	patches := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if lol.IsPatch(version) {
+			patches = append(patches, version)
+		}
+	}
+	if len(patches) == 0 {
+		return "", fmt.Errorf("could not resolve data dragon version: no version available")
+	}
+	sort.Slice(patches, func(i, j int) bool {
+		return lol.ComparePatches(patches[i], patches[j]) < 0
+	})
+
+	patch, err := lol.ResolvePatch(patches, c.patch)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve data dragon version: %w", err)
+	}
+
+	c.patch, c.patchResolved = patch, true
+	c.log.Printf("Using data dragon version %s", c.patch)
+
+	return c.patch, nil
+}
+
+// isFullVersion Whether the patch is a whole Data Dragon version, made of three numbers (e.g. 13.1.1), rather than a prefix of one
+func isFullVersion(patch string) bool {
+	return lol.IsPatch(patch) && strings.Count(patch, ".") == 2
+}
+
+func (c *Concrete) GetAllLoLChampions(ctx context.Context) ([]datadragon.ChampionDataExtended, error) {
+	patch, err := c.GetPatch(ctx)
 	if err != nil {
 		return []datadragon.ChampionDataExtended{}, err
 	}
 
-	errChan := make(chan error)
-	go func() {
-		for e := range errChan {
-			c.log.Warningf("%v", e)
+	ddAllChampionsResp, err := c.getAllChampionsList(ctx, patch)
+	if err != nil {
+		return []datadragon.ChampionDataExtended{}, err
+	}
+
+	championIDs := make([]string, 0, len(ddAllChampionsResp.Data))
+	for championID := range ddAllChampionsResp.Data {
+		championIDs = append(championIDs, championID)
+	}
+	sort.Strings(championIDs)
+
+	// Each worker writes to its own slot, so no synchronization is needed besides waiting for all of them
+	ddChampions := make([]datadragon.ChampionDataExtended, len(championIDs))
+	errs := make([]error, len(championIDs))
+	forEach(len(championIDs), c.concurrency, func(i int) {
+		if errs[i] = ctx.Err(); errs[i] != nil {
+			return // interrupted, no point in starting the remaining requests
 		}
-	}()
+		c.log.Printf("Fetching %s ...", championIDs[i])
+		ddChampions[i], errs[i] = c.getChampion(ctx, championIDs[i]) // already the data dragon id, no need to sanitize it
+	})
+
+	if err := ctx.Err(); err != nil {
+		return []datadragon.ChampionDataExtended{}, err
+	}
 
-	ddChampions := make([]datadragon.ChampionDataExtended, 0, len(ddAllChampionsResp.Data))
-	ddChampionRespChan := make(chan datadragon.ChampionDataExtended)
-	go func() {
-		for ddChampion := range ddChampionRespChan {
-			ddChampions = append(ddChampions, ddChampion)
+	fetched := make([]datadragon.ChampionDataExtended, 0, len(championIDs))
+	downloadErr := &DownloadError{Total: len(championIDs)}
+	for i, err := range errs {
+		if err != nil {
+			downloadErr.Failed = append(downloadErr.Failed, ChampionError{ChampionID: championIDs[i], Err: err})
+			continue
 		}
-	}()
+		fetched = append(fetched, ddChampions[i])
+	}
+	if len(downloadErr.Failed) > 0 {
+		return fetched, downloadErr
+	}
 
-	championNameChan := make(chan string, wgN)
+	return fetched, nil
+}
 
-	wg.Add(wgN)
-	for i := 0; i < wgN; i++ {
-		go c.getDDChampionData(championNameChan, ddChampionRespChan, errChan)
+// forEach Call fn for every index in [0, n) from at most concurrency goroutines at once, returning when all the calls are done
+func forEach(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 || concurrency > n {
+		concurrency = n
 	}
 
-	for championName := range ddAllChampionsResp.Data {
-		championNameChan <- championName
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
 	}
 
-	close(championNameChan)
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
 	wg.Wait()
+}
 
-	close(errChan)
-	close(ddChampionRespChan)
+// httpGet Unmarshal the response of the given url (of the given patch, if any) into destination
+func (c *Concrete) httpGet(ctx context.Context, patch, url string, destination interface{}) error {
+	respBody, err := c.fetch(ctx, patch, url)
+	if err != nil {
+		return err
+	}
 
-	return ddChampions, nil
+	err = json.Unmarshal(respBody, &destination)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return nil
 }
 
-func (c *Concrete) getDDChampionData(championNameChan chan string, ddChampionRespChan chan datadragon.ChampionDataExtended, errChan chan error) {
-	defer wg.Done()
-	for championName := range championNameChan {
-		c.log.Printf("Fetching %s ...", championName)
-		ddChampion, err := c.GetLoLChampion(championName)
-		if err != nil {
-			errChan <- fmt.Errorf("could not fetch champion %s: %v", championName, err)
+// fetch Response body of the given url, taken from the cache as long as it is fresh, then revalidated with its ETag/Last-Modified.
+// If Data Dragon cannot be reached at all, the cached response is used no matter how old it is.
+func (c *Concrete) fetch(ctx context.Context, patch, url string) ([]byte, error) {
+	var cached CacheEntry
+	var isCached bool
+	if c.cache != nil {
+		cached, isCached = c.cache.Get(patch, url)
+		if isCached && time.Since(cached.FetchedAt) < c.cacheTTL {
+			return cached.Body, nil
 		}
-		ddChampionRespChan <- ddChampion
 	}
-}
 
-func (c *Concrete) httpGet(url string, destination interface{}) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create API request: %w", err)
+		return nil, fmt.Errorf("failed to create API request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/json")
+	if isCached && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if isCached && cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
 
-	resp, err := c.hc.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		if isCached && ctx.Err() == nil {
+			c.log.Warningf("API request failed, using cached response of %s: %v", url, err)
+			return cached.Body, nil
+		}
+		return nil, fmt.Errorf("API request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if isCached && resp.StatusCode == http.StatusNotModified {
+		cached.FetchedAt = time.Now()
+		c.storeInCache(patch, url, cached)
+		return cached.Body, nil
+	}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("got HTTP status code %d: %s", resp.StatusCode, resp.Body)
+		return nil, fmt.Errorf("got HTTP status code %d: %s", resp.StatusCode, respBody)
 	}
 
-	err = json.Unmarshal(respBody, &destination)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	if c.cache != nil {
+		c.storeInCache(patch, url, CacheEntry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    time.Now(),
+			Body:         respBody,
+		})
 	}
 
-	return nil
+	return respBody, nil
+}
+
+// do Send the request once the rate limiter allows it, retrying it with backoff as long as it fails for a transient reason
+func (c *Concrete) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.wait(req.Context(), c.limiter.reserve()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.hc.Do(req)
+		if attempt >= c.retries || req.Context().Err() != nil || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		delay := retryDelay(resp, attempt, c.retryDelay)
+		if err != nil {
+			c.log.Warningf("Request to %s failed (%v), retrying in %s", req.URL, err, delay)
+		} else {
+			c.log.Warningf("Request to %s failed with HTTP status code %d, retrying in %s", req.URL, resp.StatusCode, delay)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := c.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
 }
 
-func (c *Concrete) GetLoLChampion(championName string) (datadragon.ChampionDataExtended, error) {
-	sanitizedChampionName := sanitizeChampionName(championName)
-	ddChampion, err := c.riotDD.DataDragon.GetChampion(sanitizedChampionName)
+// wait Sleep for the given delay, unless the context is done first
+func (c *Concrete) wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	if c.sleep != nil {
+		c.sleep(delay)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Concrete) storeInCache(patch, url string, entry CacheEntry) {
+	err := c.cache.Put(patch, url, entry)
+	if err != nil {
+		c.log.Warningf("Could not cache response of %s: %v", url, err)
+	}
+}
+
+// GetLoLChampion Champion data by its id, display name or alias, whatever the case, spaces and punctuation (e.g. kai'sa, wukong),
+// failing with an *lol.UnknownChampionError suggesting the closest champions if the name matches none of the patch
+func (c *Concrete) GetLoLChampion(ctx context.Context, championName string) (datadragon.ChampionDataExtended, error) {
+	championID, err := c.resolveChampionID(ctx, championName)
+	if err != nil {
+		return datadragon.ChampionDataExtended{}, fmt.Errorf("could not get champion from datadragon: %w", err)
+	}
+
+	ddChampion, err := c.getChampion(ctx, championID)
 	if err != nil {
 		return datadragon.ChampionDataExtended{}, fmt.Errorf("could not get champion from datadragon: %w", err)
 	}
 	return ddChampion, nil
 }
 
-// sanitizeChampionName Data Dragon APIs want champion name with first letter capitalized (e.g. TwistedFate, Jhin - plus Wukong’s internal name is monkeyking)
-func sanitizeChampionName(championName string) string {
-	switch strings.ToLower(championName) {
-	case "aurelionsol":
-		return "AurelionSol"
-	case "drmundo":
-		return "DrMundo"
-	case "jarvaniv":
-		return "JarvanIV"
-	case "kogmaw":
-		return "KogMaw"
-	case "leesin":
-		return "LeeSin"
-	case "masteryi":
-		return "MasterYi"
-	case "missfortune":
-		return "MissFortune"
-	case "monkeyking":
-		return "MonkeyKing"
-	case "wukong":
-		return "MonkeyKing"
-	case "reksai":
-		return "RekSai"
-	case "tahmkench":
-		return "TahmKench"
-	case "twistedfate":
-		return "TwistedFate"
-	case "xinzhao":
-		return "XinZhao"
-	default:
-		return cases.Title(language.English).String(championName)
+// resolveChampionID Data Dragon id of the champion the name refers to (e.g. MonkeyKing for wukong), among the champions listed by champion.json
+func (c *Concrete) resolveChampionID(ctx context.Context, championName string) (string, error) {
+	patch, err := c.GetPatch(ctx)
+	if err != nil {
+		return "", err
+	}
+	ddAllChampionsResp, err := c.getAllChampionsList(ctx, patch)
+	if err != nil {
+		return "", err
+	}
+
+	index := lol.NewChampionIndex()
+	for championID, summary := range ddAllChampionsResp.Data {
+		index.Add(championID, summary.Name)
+	}
+	return index.Resolve(championName)
+}
+
+// getAllChampionsList Champions listed by champion.json of the given patch, without their spells
+func (c *Concrete) getAllChampionsList(ctx context.Context, patch string) (dataDragonLoLAllChampionsResponse, error) {
+	var ddAllChampionsResp dataDragonLoLAllChampionsResponse
+	err := c.httpGet(ctx, patch, c.baseURL+fmt.Sprintf(dDragonAllChampionsPath, patch, c.locale), &ddAllChampionsResp)
+	return ddAllChampionsResp, err
+}
+
+// getChampion Champion data by its data dragon id (e.g. MonkeyKing), where the champion version is always the one the data was fetched from
+func (c *Concrete) getChampion(ctx context.Context, championID string) (datadragon.ChampionDataExtended, error) {
+	patch, err := c.GetPatch(ctx)
+	if err != nil {
+		return datadragon.ChampionDataExtended{}, err
+	}
+
+	var ddChampionResp dataDragonLoLChampionResponse
+	err = c.httpGet(ctx, patch, c.baseURL+fmt.Sprintf(dDragonChampionPath, patch, c.locale, championID), &ddChampionResp)
+	if err != nil {
+		return datadragon.ChampionDataExtended{}, err
 	}
+
+	ddChampion, ok := ddChampionResp.Data[championID]
+	if !ok {
+		return datadragon.ChampionDataExtended{}, fmt.Errorf("champion %s not found in data dragon version %s", championID, patch)
+	}
+	ddChampion.Version = patch
+
+	return ddChampion, nil
 }
@@ -1,79 +1,254 @@
 package riot
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/logger/loggertest"
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/KnutZuidema/golio/datadragon"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestSanitizeChampionName(t *testing.T) {
-	t.Run("sanitizeChampionName Jhin", func(t *testing.T) {
-		championName := sanitizeChampionName("jHiN")
-		assert.Equal(t, "Jhin", championName)
+// newTestDataDragon Fake Data Dragon serving versions.json plus Jhin and Ahri data for the given patches only (in en_US and it_IT, the title being the locale)
+func newTestDataDragon(t *testing.T, patches ...string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(dDragonVersionsPath, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `["13.1.1", "12.23.1", "12.3.1", "lolpatch_7.20"]`)
 	})
+	for _, patch := range patches {
+		for _, locale := range []string{dDragonDefaultLocale, "it_IT"} {
+			mux.HandleFunc(fmt.Sprintf(dDragonAllChampionsPath, patch, locale), func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"data": {"Jhin": {}, "Ahri": {}}}`)
+			})
+			for _, id := range []string{"Jhin", "Ahri"} {
+				body := fmt.Sprintf(`{"data": {"%s": {"id": "%s", "name": "%s", "title": "%s"}}}`, id, id, id, locale)
+				mux.HandleFunc(fmt.Sprintf(dDragonChampionPath, patch, locale, id), func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, body)
+				})
+			}
+		}
+	}
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(srv *httptest.Server, patch string) *Concrete {
+	return &Concrete{log: &loggertest.Logger{}, hc: srv.Client(), baseURL: srv.URL, locale: dDragonDefaultLocale, patch: patch}
+}
+
+func TestGetPatch(t *testing.T) {
+	srv := newTestDataDragon(t)
 
-	t.Run("sanitizeChampionName AurelionSol", func(t *testing.T) {
-		championName := sanitizeChampionName("Aurelionsol")
-		assert.Equal(t, "AurelionSol", championName)
+	t.Run("latest version", func(t *testing.T) {
+		patch, err := newTestClient(srv, "").GetPatch(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "13.1.1", patch)
 	})
 
-	t.Run("sanitizeChampionName DrMundo", func(t *testing.T) {
-		championName := sanitizeChampionName("dRMundo")
-		assert.Equal(t, "DrMundo", championName)
+	t.Run("given version", func(t *testing.T) {
+		patch, err := newTestClient(srv, "12.3.1").GetPatch(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "12.3.1", patch)
 	})
+	t.Run("version prefix", func(t *testing.T) {
+		patch, err := newTestClient(srv, "12.3").GetPatch(context.Background())
 
-	t.Run("sanitizeChampionName JarvanIV", func(t *testing.T) {
-		championName := sanitizeChampionName("jarvanIV")
-		assert.Equal(t, "JarvanIV", championName)
+		assert.Nil(t, err)
+		assert.Equal(t, "12.3.1", patch)
 	})
 
-	t.Run("sanitizeChampionName KogMaw", func(t *testing.T) {
-		championName := sanitizeChampionName("Kogmaw")
-		assert.Equal(t, "KogMaw", championName)
+	t.Run("unknown version prefix", func(t *testing.T) {
+		_, err := newTestClient(srv, "12.4").GetPatch(context.Background())
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := newTestClient(srv, "latest").GetPatch(context.Background())
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetLoLChampion(t *testing.T) {
+	t.Run("latest version", func(t *testing.T) {
+		srv := newTestDataDragon(t, "13.1.1")
+
+		champion, err := newTestClient(srv, "").GetLoLChampion(context.Background(), "jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Jhin", champion.ID)
+		assert.Equal(t, "13.1.1", champion.Version)
 	})
 
-	t.Run("sanitizeChampionName LeeSin", func(t *testing.T) {
-		championName := sanitizeChampionName("Leesin")
-		assert.Equal(t, "LeeSin", championName)
+	t.Run("given version", func(t *testing.T) {
+		srv := newTestDataDragon(t, "12.3.1")
+
+		champion, err := newTestClient(srv, "12.3.1").GetLoLChampion(context.Background(), "jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "12.3.1", champion.Version)
 	})
 
-	t.Run("sanitizeChampionName MasterYi", func(t *testing.T) {
-		championName := sanitizeChampionName("Masteryi")
-		assert.Equal(t, "MasterYi", championName)
+	t.Run("given locale", func(t *testing.T) {
+		srv := newTestDataDragon(t, "13.1.1")
+		client := newTestClient(srv, "")
+		client.locale = "it_IT"
+
+		champion, err := client.GetLoLChampion(context.Background(), "jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "it_IT", champion.Title)
 	})
 
-	t.Run("sanitizeChampionName MissFortune", func(t *testing.T) {
-		championName := sanitizeChampionName("Missfortune")
-		assert.Equal(t, "MissFortune", championName)
+	t.Run("not found", func(t *testing.T) {
+		srv := newTestDataDragon(t, "13.1.1")
+
+		_, err := newTestClient(srv, "").GetLoLChampion(context.Background(), "teemo")
+
+		assert.NotNil(t, err)
+		assert.True(t, errors.Is(err, lol.ErrChampionNotFound))
 	})
 
-	t.Run("sanitizeChampionName MonkeyKing", func(t *testing.T) {
-		championName := sanitizeChampionName("Monkeyking")
-		assert.Equal(t, "MonkeyKing", championName)
+	t.Run("display name and alias", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(fmt.Sprintf(dDragonAllChampionsPath, "13.1.1", dDragonDefaultLocale), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data": {"Kaisa": {"name": "Kai'Sa"}, "MonkeyKing": {"name": "Wukong"}}}`)
+		})
+		for _, id := range []string{"Kaisa", "MonkeyKing"} {
+			body := fmt.Sprintf(`{"data": {"%s": {"id": "%s"}}}`, id, id)
+			mux.HandleFunc(fmt.Sprintf(dDragonChampionPath, "13.1.1", dDragonDefaultLocale, id), func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, body)
+			})
+		}
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+		client := newTestClient(srv, "13.1.1")
+
+		for name, id := range map[string]string{"kai'sa": "Kaisa", "KAISA": "Kaisa", "wukong": "MonkeyKing", "monkeyking": "MonkeyKing"} {
+			champion, err := client.GetLoLChampion(context.Background(), name)
+
+			assert.Nil(t, err, name)
+			assert.Equal(t, id, champion.ID, name)
+		}
+
+		_, err := client.GetLoLChampion(context.Background(), "kaisaa")
+
+		var unknownErr *lol.UnknownChampionError
+		assert.True(t, errors.As(err, &unknownErr))
+		assert.Equal(t, []string{"Kai'Sa"}, unknownErr.Suggestions)
 	})
+}
+
+func TestGetAllLoLChampions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		srv := newTestDataDragon(t, "12.3.1")
 
-	t.Run("sanitizeChampionName Wukong", func(t *testing.T) {
-		championName := sanitizeChampionName("Monkeyking")
-		assert.Equal(t, "MonkeyKing", championName)
+		champions, err := newTestClient(srv, "12.3.1").GetAllLoLChampions(context.Background())
+
+		assert.Nil(t, err)
+		assert.Len(t, champions, 2)
+		for _, champion := range champions {
+			assert.Equal(t, "12.3.1", champion.Version)
+		}
 	})
 
-	t.Run("sanitizeChampionName RekSai", func(t *testing.T) {
-		championName := sanitizeChampionName("Reksai")
-		assert.Equal(t, "RekSai", championName)
+	t.Run("partial failure", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc(fmt.Sprintf(dDragonAllChampionsPath, "13.1.1", dDragonDefaultLocale), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data": {"Jhin": {}, "Teemo": {}, "Ahri": {}}}`)
+		})
+		for _, id := range []string{"Jhin", "Ahri"} {
+			body := fmt.Sprintf(`{"data": {"%s": {"id": "%s"}}}`, id, id)
+			mux.HandleFunc(fmt.Sprintf(dDragonChampionPath, "13.1.1", dDragonDefaultLocale, id), func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, body)
+			})
+		}
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		champions, err := newTestClient(srv, "13.1.1").GetAllLoLChampions(context.Background())
+
+		var downloadErr *DownloadError
+		assert.True(t, errors.As(err, &downloadErr))
+		assert.Equal(t, 3, downloadErr.Total)
+		assert.Len(t, downloadErr.Failed, 1)
+		assert.Equal(t, "Teemo", downloadErr.Failed[0].ChampionID)
+		assert.Len(t, champions, 2)
+		assert.Equal(t, "Ahri", champions[0].ID)
+		assert.Equal(t, "Jhin", champions[1].ID)
 	})
 
-	t.Run("sanitizeChampionName TahmKench", func(t *testing.T) {
-		championName := sanitizeChampionName("Tahmkench")
-		assert.Equal(t, "TahmKench", championName)
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mux := http.NewServeMux()
+		mux.HandleFunc(fmt.Sprintf(dDragonAllChampionsPath, "13.1.1", dDragonDefaultLocale), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"data": {"Jhin": {}, "Ahri": {}}}`)
+		})
+		mux.HandleFunc("/cdn/13.1.1/data/en_US/champion/", func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			<-r.Context().Done() // hang until the client gives up
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		champions, err := newTestClient(srv, "13.1.1").GetAllLoLChampions(ctx)
+
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Empty(t, champions)
 	})
 
-	t.Run("sanitizeChampionName TwistedFate", func(t *testing.T) {
-		championName := sanitizeChampionName("Twistedfate")
-		assert.Equal(t, "TwistedFate", championName)
+	t.Run("concurrent downloads", func(t *testing.T) {
+		srv := newTestDataDragon(t, "12.3.1")
+		client := newTestClient(srv, "12.3.1")
+		client.concurrency = 1
+
+		var wg sync.WaitGroup
+		results := make([][]datadragon.ChampionDataExtended, 5)
+		errs := make([]error, len(results))
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = client.GetAllLoLChampions(context.Background())
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range results {
+			assert.Nil(t, errs[i])
+			assert.Len(t, results[i], 2)
+		}
 	})
+}
 
-	t.Run("sanitizeChampionName XinZhao", func(t *testing.T) {
-		championName := sanitizeChampionName("Xinzhao")
-		assert.Equal(t, "XinZhao", championName)
+func TestForEach(t *testing.T) {
+	var running, maxRunning, calls int32
+	forEach(20, 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&calls, 1)
 	})
+
+	assert.Equal(t, int32(20), calls)
+	assert.LessOrEqual(t, maxRunning, int32(3))
 }
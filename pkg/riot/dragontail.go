@@ -0,0 +1,145 @@
+package riot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/KnutZuidema/golio/datadragon"
+)
+
+// dragontailChampionPathRegexp Champion file inside a Data Dragon "dragontail" archive, i.e. <version>/data/<locale>/champion/<id>.json
+var dragontailChampionPathRegexp = regexp.MustCompile(`(?:^|/)(\d+(?:\.\d+)+)/data/([^/]+)/champion/([^/]+)\.json$`)
+
+// ReadDragontail Champions data of the given locale (e.g. en_US) from a Data Dragon "dragontail" archive, either the
+// official tarball (dragontail-<version>.tgz) or a directory it was extracted to, without any network access.
+// Each champion version is the one of the directory it was found in.
+func ReadDragontail(dragontailPath, locale string) ([]datadragon.ChampionDataExtended, error) {
+	if locale == "" {
+		locale = dDragonDefaultLocale
+	}
+
+	info, err := os.Stat(dragontailPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var ddChampions []datadragon.ChampionDataExtended
+	if info.IsDir() {
+		ddChampions, err = readDragontailDir(os.DirFS(dragontailPath), locale)
+	} else {
+		ddChampions, err = readDragontailArchive(dragontailPath, locale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(ddChampions) == 0 {
+		return nil, fmt.Errorf("no %s champion found in %s (expected <version>/data/%s/champion/*.json)", locale, dragontailPath, locale)
+	}
+
+	sort.Slice(ddChampions, func(i, j int) bool {
+		if ddChampions[i].Version != ddChampions[j].Version {
+			return lol.ComparePatches(ddChampions[i].Version, ddChampions[j].Version) < 0
+		}
+		return ddChampions[i].ID < ddChampions[j].ID
+	})
+	return ddChampions, nil
+}
+
+func readDragontailDir(fsys fs.FS, locale string) ([]datadragon.ChampionDataExtended, error) {
+	var ddChampions []datadragon.ChampionDataExtended
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		version, championID, ok := matchDragontailChampion(p, locale)
+		if d.IsDir() || !ok {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		ddChampion, err := parseDragontailChampion(p, version, championID, data)
+		if err != nil {
+			return err
+		}
+		ddChampions = append(ddChampions, ddChampion)
+		return nil
+	})
+	return ddChampions, err
+}
+
+func readDragontailArchive(archivePath, locale string) ([]datadragon.ChampionDataExtended, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s as a gzip archive: %w", archivePath, err)
+	}
+	defer gz.Close()
+
+	var ddChampions []datadragon.ChampionDataExtended
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", archivePath, err)
+		}
+		version, championID, ok := matchDragontailChampion(hdr.Name, locale)
+		if hdr.Typeflag != tar.TypeReg || !ok {
+			continue // most of the archive is made of images, skip them without reading
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", hdr.Name, err)
+		}
+		ddChampion, err := parseDragontailChampion(hdr.Name, version, championID, data)
+		if err != nil {
+			return nil, err
+		}
+		ddChampions = append(ddChampions, ddChampion)
+	}
+	return ddChampions, nil
+}
+
+// matchDragontailChampion Version and champion id of the given file, if it is a champion file of the given locale
+func matchDragontailChampion(filePath, locale string) (version, championID string, ok bool) {
+	match := dragontailChampionPathRegexp.FindStringSubmatch(filePath)
+	if match == nil || match[2] != locale {
+		return "", "", false
+	}
+	return match[1], match[3], true
+}
+
+func parseDragontailChampion(filePath, version, championID string, data []byte) (datadragon.ChampionDataExtended, error) {
+	var ddChampionResp dataDragonLoLChampionResponse
+	err := json.Unmarshal(data, &ddChampionResp)
+	if err != nil {
+		return datadragon.ChampionDataExtended{}, fmt.Errorf("failed to unmarshal %s: %w", filePath, err)
+	}
+
+	ddChampion, ok := ddChampionResp.Data[championID]
+	if !ok {
+		return datadragon.ChampionDataExtended{}, fmt.Errorf("champion %s not found in %s", championID, filePath)
+	}
+	ddChampion.Version = version
+
+	return ddChampion, nil
+}
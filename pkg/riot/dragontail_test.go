@@ -0,0 +1,96 @@
+package riot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// getDragontailTestFiles Minimal dragontail layout: a couple of champions in two locales, plus files that must be ignored
+func getDragontailTestFiles() map[string]string {
+	files := map[string]string{
+		"13.1.1/data/en_US/champion.json":      `{"data": {}}`,
+		"13.1.1/img/champion/Jhin.png":         "not a json",
+		"13.1.1/data/it_IT/champion/Jhin.json": `{"data": {"Jhin": {"id": "Jhin", "name": "Jhin", "title": "il Virtuoso"}}}`,
+	}
+	for _, id := range []string{"Jhin", "MonkeyKing"} {
+		files[fmt.Sprintf("13.1.1/data/en_US/champion/%s.json", id)] = fmt.Sprintf(`{"version": "13.1.1", "data": {"%s": {"id": "%s", "name": "%s"}}}`, id, id, id)
+	}
+	return files
+}
+
+func TestReadDragontail(t *testing.T) {
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		for name, content := range getDragontailTestFiles() {
+			assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+		}
+
+		ddChampions, err := ReadDragontail(dir, "")
+
+		assert.Nil(t, err)
+		assert.Len(t, ddChampions, 2)
+		assert.Equal(t, "Jhin", ddChampions[0].ID)
+		assert.Equal(t, "MonkeyKing", ddChampions[1].ID)
+		assert.Equal(t, "13.1.1", ddChampions[1].Version)
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "dragontail-13.1.1.tgz")
+		f, err := os.Create(archivePath)
+		assert.Nil(t, err)
+		gz := gzip.NewWriter(f)
+		tw := tar.NewWriter(gz)
+		for name, content := range getDragontailTestFiles() {
+			assert.Nil(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err = tw.Write([]byte(content))
+			assert.Nil(t, err)
+		}
+		assert.Nil(t, tw.Close())
+		assert.Nil(t, gz.Close())
+		assert.Nil(t, f.Close())
+
+		ddChampions, err := ReadDragontail(archivePath, "it_IT")
+
+		assert.Nil(t, err)
+		assert.Len(t, ddChampions, 1)
+		assert.Equal(t, "il Virtuoso", ddChampions[0].Title)
+		assert.Equal(t, "13.1.1", ddChampions[0].Version)
+	})
+
+	t.Run("versions sorted numerically", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, version := range []string{"14.20.1", "9.24.1", "14.3.1", "14.3"} {
+			name := filepath.Join(dir, version, "data", "en_US", "champion", "Jhin.json")
+			assert.Nil(t, os.MkdirAll(filepath.Dir(name), 0700))
+			assert.Nil(t, os.WriteFile(name, []byte(`{"data": {"Jhin": {"id": "Jhin", "name": "Jhin"}}}`), 0600))
+		}
+
+		ddChampions, err := ReadDragontail(dir, "")
+
+		assert.Nil(t, err)
+		var versions []string
+		for _, ddChampion := range ddChampions {
+			versions = append(versions, ddChampion.Version)
+		}
+		assert.Equal(t, []string{"9.24.1", "14.3", "14.3.1", "14.20.1"}, versions)
+	})
+
+	t.Run("no champions", func(t *testing.T) {
+		_, err := ReadDragontail(t.TempDir(), "")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		_, err := ReadDragontail(filepath.Join(t.TempDir(), "dragontail.tgz"), "")
+
+		assert.NotNil(t, err)
+	})
+}
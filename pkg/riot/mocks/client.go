@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	datadragon "github.com/KnutZuidema/golio/datadragon"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,13 @@ type Client struct {
 	mock.Mock
 }
 
-// GetAllLoLChampions provides a mock function with given fields:
-func (_m *Client) GetAllLoLChampions() ([]datadragon.ChampionDataExtended, error) {
-	ret := _m.Called()
+// GetAllLoLChampions provides a mock function with given fields: ctx
+func (_m *Client) GetAllLoLChampions(ctx context.Context) ([]datadragon.ChampionDataExtended, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []datadragon.ChampionDataExtended
-	if rf, ok := ret.Get(0).(func() []datadragon.ChampionDataExtended); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []datadragon.ChampionDataExtended); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]datadragon.ChampionDataExtended)
@@ -26,8 +28,8 @@ func (_m *Client) GetAllLoLChampions() ([]datadragon.ChampionDataExtended, error
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,20 +37,41 @@ func (_m *Client) GetAllLoLChampions() ([]datadragon.ChampionDataExtended, error
 	return r0, r1
 }
 
-// GetLoLChampion provides a mock function with given fields: championName
-func (_m *Client) GetLoLChampion(championName string) (datadragon.ChampionDataExtended, error) {
-	ret := _m.Called(championName)
+// GetLoLChampion provides a mock function with given fields: ctx, championName
+func (_m *Client) GetLoLChampion(ctx context.Context, championName string) (datadragon.ChampionDataExtended, error) {
+	ret := _m.Called(ctx, championName)
 
 	var r0 datadragon.ChampionDataExtended
-	if rf, ok := ret.Get(0).(func(string) datadragon.ChampionDataExtended); ok {
-		r0 = rf(championName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) datadragon.ChampionDataExtended); ok {
+		r0 = rf(ctx, championName)
 	} else {
 		r0 = ret.Get(0).(datadragon.ChampionDataExtended)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(championName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, championName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPatch provides a mock function with given fields: ctx
+func (_m *Client) GetPatch(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,96 @@
+package riot
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRetries    = 3
+	defaultRetryDelay = 500 * time.Millisecond
+	maxRetryDelay     = time.Minute // upper bound of any wait between two attempts, Retry-After included
+	defaultRateLimit  = 20          // requests per second
+	defaultRateBurst  = 20
+)
+
+// rateLimiter Token bucket shared by all the requests of a client: up to burst requests at once, then rate requests per second
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// reserve Take a token, returning how long to wait before it can be used (zero if there was one available)
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// isRetryable Whether a failed request is worth retrying, i.e. it was rate limited (429), Data Dragon had an issue (5xx) or it timed out
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryDelay How long to wait before the next attempt: what the Retry-After header asks for if any,
+// otherwise an exponential backoff (with jitter, so that concurrent requests do not retry all at once)
+func retryDelay(resp *http.Response, attempt int, baseDelay time.Duration) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+
+	backoff := float64(baseDelay) * math.Pow(2, float64(attempt))
+	backoff = backoff/2 + rand.Float64()*backoff/2
+	return time.Duration(math.Min(backoff, float64(maxRetryDelay)))
+}
+
+// parseRetryAfter Delay asked by a Retry-After header, either in seconds or as an HTTP date
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay, true
+}
@@ -0,0 +1,158 @@
+package riot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestFlakyDataDragon Fake Data Dragon failing the first requests with the given responses, counting the requests it gets
+func newTestFlakyDataDragon(t *testing.T, requests *int32, failures ...func(w http.ResponseWriter)) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(requests, 1)
+		if int(n) <= len(failures) {
+			failures[n-1](w)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"Jhin": {"id": "Jhin", "name": "Jhin"}}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func withStatus(statusCode int, headers ...string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for i := 0; i+1 < len(headers); i += 2 {
+			w.Header().Set(headers[i], headers[i+1])
+		}
+		w.WriteHeader(statusCode)
+	}
+}
+
+// newTestRetryClient Client retrying up to the given times, recording the delays it waits for instead of sleeping
+func newTestRetryClient(srv *httptest.Server, retries int, delays *[]time.Duration) *Concrete {
+	client := newTestClient(srv, "13.1.1")
+	client.retries, client.retryDelay = retries, 100*time.Millisecond
+	client.sleep = func(d time.Duration) { *delays = append(*delays, d) }
+	return client
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("server errors", func(t *testing.T) {
+		var requests int32
+		var delays []time.Duration
+		srv := newTestFlakyDataDragon(t, &requests, withStatus(http.StatusInternalServerError), withStatus(http.StatusServiceUnavailable))
+
+		champion, err := newTestRetryClient(srv, 3, &delays).getChampion(context.Background(), "Jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Jhin", champion.ID)
+		assert.Equal(t, int32(3), requests)
+		assert.Len(t, delays, 2)
+		assert.GreaterOrEqual(t, delays[0], 50*time.Millisecond)
+		assert.LessOrEqual(t, delays[0], 100*time.Millisecond)
+		assert.GreaterOrEqual(t, delays[1], 100*time.Millisecond)
+		assert.LessOrEqual(t, delays[1], 200*time.Millisecond)
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		var requests int32
+		var delays []time.Duration
+		srv := newTestFlakyDataDragon(t, &requests, withStatus(http.StatusTooManyRequests, "Retry-After", "7"))
+
+		_, err := newTestRetryClient(srv, 3, &delays).getChampion(context.Background(), "Jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), requests)
+		assert.Equal(t, []time.Duration{7 * time.Second}, delays)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		var requests int32
+		var delays []time.Duration
+		srv := newTestFlakyDataDragon(t, &requests, withStatus(http.StatusBadGateway), withStatus(http.StatusBadGateway), withStatus(http.StatusBadGateway))
+
+		_, err := newTestRetryClient(srv, 2, &delays).getChampion(context.Background(), "Jhin")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, int32(3), requests)
+		assert.Len(t, delays, 2)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		var requests int32
+		var delays []time.Duration
+		srv := newTestFlakyDataDragon(t, &requests, withStatus(http.StatusNotFound))
+
+		_, err := newTestRetryClient(srv, 3, &delays).getChampion(context.Background(), "Jhin")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, int32(1), requests)
+		assert.Empty(t, delays)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		var requests int32
+		var delays []time.Duration
+		srv := newTestFlakyDataDragon(t, &requests, func(w http.ResponseWriter) { time.Sleep(200 * time.Millisecond) })
+		client := newTestRetryClient(srv, 3, &delays)
+		client.hc.Timeout = 50 * time.Millisecond
+
+		champion, err := client.getChampion(context.Background(), "Jhin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Jhin", champion.ID)
+		assert.Equal(t, int32(2), requests)
+		assert.Len(t, delays, 1)
+	})
+}
+
+func TestRetryCancelled(t *testing.T) {
+	var requests int32
+	srv := newTestFlakyDataDragon(t, &requests, withStatus(http.StatusServiceUnavailable))
+	client := newTestClient(srv, "13.1.1")
+	client.retries, client.retryDelay = 3, time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.getChampion(ctx, "Jhin")
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), requests)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(10, 2)
+
+	assert.Zero(t, limiter.reserve())
+	assert.Zero(t, limiter.reserve())
+	assert.InDelta(t, 100*time.Millisecond, limiter.reserve(), float64(10*time.Millisecond))
+	assert.InDelta(t, 200*time.Millisecond, limiter.reserve(), float64(10*time.Millisecond))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		retryAfter string
+		delay      time.Duration
+		ok         bool
+	}{
+		{retryAfter: "", ok: false},
+		{retryAfter: "soon", ok: false},
+		{retryAfter: "3", delay: 3 * time.Second, ok: true},
+		{retryAfter: "3600", delay: maxRetryDelay, ok: true},
+		{retryAfter: "Mon, 02 Jan 2006 15:04:05 GMT", delay: 0, ok: true},
+	}
+
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.retryAfter)
+		assert.Equal(t, tt.ok, ok, tt.retryAfter)
+		assert.Equal(t, tt.delay, delay, tt.retryAfter)
+	}
+}
@@ -0,0 +1,236 @@
+package riot
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/KnutZuidema/golio/datadragon"
+)
+
+// Confidence in a damage recovered from Data Dragon, depending on where it was found
+const (
+	confidenceLeveltipAndTooltip = 0.9  // labelled as damage by the leveltip, and described as such by the tooltip
+	confidenceLeveltip           = 0.75 // labelled as damage by the leveltip only
+	confidenceTypedTooltip       = 0.6  // described by the tooltip as a given type of damage
+	confidenceTooltip            = 0.5  // described by the tooltip as damage, of unknown type
+	confidenceFirstEffect        = 0.2  // first effect, the historical guess, as nothing points at any damage
+)
+
+// tooltipPlaceholder Placeholder of a tooltip, leveltip or effectBurn value: {{ e1 }} (effect), {{ a1 }} or {{ f1 }} (var), with their NL (next level) variant
+var tooltipPlaceholder = regexp.MustCompile(`\{\{\s*([aef])(\d+)(?:NL)?\s*\}\}`)
+
+var tooltipTag = regexp.MustCompile(`<[^>]*>`)
+
+// ratioNames Names of the Data Dragon var links, the same ones CommunityDragon uses
+var ratioNames = map[string]string{
+	"attackdamage":      "TotalADRatio",
+	"bonusattackdamage": "BonusADRatio",
+	"spelldamage":       "APRatio",
+}
+
+// SpellDamage Damage of a spell as recovered from its Data Dragon tooltip, leveltip, effects and vars
+type SpellDamage struct {
+	Values     []float64            // by rank, nil if no damage was found
+	Type       string               // lol.DamagePhysical, lol.DamageMagic or lol.DamageTrue, empty if unknown
+	Effect     int                  // effect the values were taken from (e.g. 2 for {{ e2 }}), 0 if none
+	Ratios     map[string][]float64 // damage scaling by rank, by scaling stat (e.g. TotalADRatio)
+	Confidence float64              // how likely (0-1) Values is actually the spell damage
+}
+
+// ParseSpellDamage Find out which effect of the spell is its damage: the one the leveltip labels as damage, otherwise the first one
+// the tooltip describes as damage, falling back on the first effect (with a low confidence) when neither points at any
+func ParseSpellDamage(spell datadragon.SpellData) SpellDamage {
+	leveltipEffect := leveltipDamageEffect(spell)
+	tooltipEffect, segment := tooltipDamageEffect(spell, leveltipEffect)
+
+	var damage SpellDamage
+	switch {
+	case leveltipEffect != 0 && leveltipEffect == tooltipEffect:
+		damage = SpellDamage{Effect: leveltipEffect, Confidence: confidenceLeveltipAndTooltip}
+	case leveltipEffect != 0:
+		damage = SpellDamage{Effect: leveltipEffect, Confidence: confidenceLeveltip}
+		segment = ""
+	case tooltipEffect != 0:
+		damage = SpellDamage{Effect: tooltipEffect, Confidence: confidenceTooltip}
+	default:
+		if values := effectValues(spell, 1); values != nil && !isPercentage(spell.Tooltip, 1) && !hasNegativeValue(values) {
+			return SpellDamage{Values: values, Effect: 1, Confidence: confidenceFirstEffect}
+		}
+		return SpellDamage{}
+	}
+
+	damage.Values = effectValues(spell, damage.Effect)
+	if damage.Values == nil {
+		return SpellDamage{}
+	}
+	damage.Type = damageType(segment)
+	if damage.Type != "" && damage.Confidence == confidenceTooltip {
+		damage.Confidence = confidenceTypedTooltip
+	}
+	damage.Ratios = segmentRatios(spell, segment)
+
+	return damage
+}
+
+// leveltipDamageEffect First effect whose leveltip label is about damage (e.g. "Damage", "Magic Damage"), 0 if none
+func leveltipDamageEffect(spell datadragon.SpellData) int {
+	for i, label := range spell.Leveltip.Label {
+		label = strings.ToLower(label)
+		if !strings.Contains(label, "damage") || strings.Contains(label, "%") || strings.Contains(label, "reduction") {
+			continue
+		}
+		if i >= len(spell.Leveltip.Effect) {
+			break
+		}
+		for _, m := range tooltipPlaceholder.FindAllStringSubmatch(spell.Leveltip.Effect[i], -1) {
+			if m[1] == "e" {
+				effect, _ := strconv.Atoi(m[2])
+				return effect
+			}
+		}
+	}
+	return 0
+}
+
+// tooltipDamageEffect Effect the tooltip describes as damage (the preferred one, if it does), 0 if none, along with the sentence describing it
+func tooltipDamageEffect(spell datadragon.SpellData, preferred int) (int, string) {
+	var found int
+	var foundSegment string
+	for _, loc := range tooltipPlaceholder.FindAllStringSubmatchIndex(spell.Tooltip, -1) {
+		if spell.Tooltip[loc[2]:loc[3]] != "e" {
+			continue
+		}
+		effect, _ := strconv.Atoi(spell.Tooltip[loc[4]:loc[5]])
+		if isPercentage(spell.Tooltip, effect) {
+			continue
+		}
+
+		segment := tooltipSegment(spell.Tooltip, loc[0], loc[1])
+		if !strings.Contains(strings.ToLower(tooltipTag.ReplaceAllString(segment, "")), "damage") {
+			continue
+		}
+		if effect == preferred {
+			return effect, segment
+		}
+		if found == 0 {
+			found, foundSegment = effect, segment
+		}
+	}
+	return found, foundSegment
+}
+
+// tooltipSegment Sentence of the tooltip around the given range, sentences being split by full stops and line breaks
+func tooltipSegment(tooltip string, start, end int) string {
+	from := 0
+	for _, sep := range []string{". ", "<br"} {
+		if i := strings.LastIndex(tooltip[:start], sep); i >= 0 && i+len(sep) > from {
+			from = i + len(sep)
+		}
+	}
+	to := len(tooltip)
+	for _, sep := range []string{". ", "<br"} {
+		if i := strings.Index(tooltip[end:], sep); i >= 0 && end+i < to {
+			to = end + i
+		}
+	}
+	return tooltip[from:to]
+}
+
+// isPercentage Whether the tooltip shows the given effect as a percentage (e.g. a slow), hence not as a damage
+func isPercentage(tooltip string, effect int) bool {
+	for _, loc := range tooltipPlaceholder.FindAllStringSubmatchIndex(tooltip, -1) {
+		if tooltip[loc[2]:loc[3]] == "e" && tooltip[loc[4]:loc[5]] == strconv.Itoa(effect) && strings.HasPrefix(tooltip[loc[1]:], "%") {
+			return true
+		}
+	}
+	return false
+}
+
+// damageType Type of the damage described by the segment, the lol damage types being named as the tooltip tags (e.g. <magicDamage>)
+func damageType(segment string) string {
+	segment = strings.ToLower(segment)
+	for _, damageType := range []string{lol.DamagePhysical, lol.DamageMagic, lol.DamageTrue} {
+		if strings.Contains(segment, "<"+damageType+"damage>") || strings.Contains(segment, damageType+" damage") {
+			return damageType
+		}
+	}
+	return ""
+}
+
+// segmentRatios Ratios of the vars ({{ a1 }}, {{ f1 }}) shown along with the damage, repeated for every rank
+func segmentRatios(spell datadragon.SpellData, segment string) map[string][]float64 {
+	var ratios map[string][]float64
+	for _, m := range tooltipPlaceholder.FindAllStringSubmatch(segment, -1) {
+		if m[1] == "e" {
+			continue
+		}
+		for _, v := range spell.Vars {
+			if v.Key != m[1]+m[2] || v.Coefficient == 0 {
+				continue
+			}
+			name, ok := ratioNames[v.Link]
+			if !ok {
+				name = v.Link
+			}
+			if ratios == nil {
+				ratios = map[string][]float64{}
+			}
+			ratios[name] = make([]float64, spell.MaxRank)
+			for i := range ratios[name] {
+				ratios[name][i] = v.Coefficient
+			}
+		}
+	}
+	return ratios
+}
+
+// effectValues Values by rank of the given effect, read from effectBurn when the effect array is missing, nil if there is none or all are zero
+func effectValues(spell datadragon.SpellData, effect int) []float64 {
+	var values []float64
+	if effect < len(spell.Effect) && len(spell.Effect[effect]) > 0 {
+		values = spell.Effect[effect]
+	} else if effect < len(spell.EffectBurn) && spell.EffectBurn[effect] != "" {
+		values = parseEffectBurn(spell.EffectBurn[effect], spell.MaxRank)
+	}
+
+	if spell.MaxRank > 0 && len(values) > spell.MaxRank {
+		values = values[:spell.MaxRank]
+	}
+	for _, value := range values {
+		if value != 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+// hasNegativeValue Whether any of the effect values is negative, as for a slow or a reduction (e.g. -0.35), which is never a damage
+func hasNegativeValue(values []float64) bool {
+	for _, value := range values {
+		if value < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEffectBurn Values of an effectBurn string, either one per rank (e.g. 60/95/130) or the same for all of them (e.g. 60)
+func parseEffectBurn(burn string, maxRank int) []float64 {
+	parts := strings.Split(burn, "/")
+	values := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil
+		}
+		values = append(values, value)
+	}
+	if len(values) == 1 {
+		for len(values) < maxRank {
+			values = append(values, values[0])
+		}
+	}
+	return values
+}
@@ -0,0 +1,121 @@
+package riot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/J4NN0/league-of-legends-fight-tactics/pkg/lol"
+	"github.com/KnutZuidema/golio/datadragon"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSpell(t *testing.T, spellJSON string) datadragon.SpellData {
+	var spell datadragon.SpellData
+	err := json.Unmarshal([]byte(spellJSON), &spell)
+	assert.Nil(t, err)
+	return spell
+}
+
+func TestParseSpellDamage(t *testing.T) {
+	t.Run("leveltip and tooltip", func(t *testing.T) {
+		spell := newTestSpell(t, `{
+			"id": "AnnieQ",
+			"tooltip": "Annie hurls a Mana-infused fireball, dealing <magicDamage>{{ e1 }} <scaleAP>(+{{ a1 }})</scaleAP> magic damage</magicDamage>. If the target dies, Annie refunds {{ e2 }}% of the Mana cost.",
+			"leveltip": {"label": ["Damage", "Mana Cost"], "effect": ["{{ e1 }} -> {{ e1NL }}", "{{ cost }} -> {{ costNL }}"]},
+			"maxrank": 5,
+			"effect": [null, [80, 115, 150, 185, 220], [50, 50, 50, 50, 50]],
+			"vars": [{"link": "spelldamage", "coeff": 0.8, "key": "a1"}]
+		}`)
+
+		damage := ParseSpellDamage(spell)
+
+		assert.Equal(t, SpellDamage{
+			Values:     []float64{80, 115, 150, 185, 220},
+			Type:       lol.DamageMagic,
+			Effect:     1,
+			Ratios:     map[string][]float64{"APRatio": {0.8, 0.8, 0.8, 0.8, 0.8}},
+			Confidence: confidenceLeveltipAndTooltip,
+		}, damage)
+	})
+
+	t.Run("damage not in first effect", func(t *testing.T) {
+		spell := newTestSpell(t, `{
+			"id": "AsheW",
+			"tooltip": "Ashe fires arrows in a cone, slowing by {{ e1 }}% for 2 seconds. Each arrow deals <physicalDamage>{{ e2 }} (+{{ f1 }})</physicalDamage> physical damage.",
+			"leveltip": {"label": ["Slow %", "Damage"], "effect": ["{{ e1 }}% -> {{ e1NL }}%", "{{ e2 }} -> {{ e2NL }}"]},
+			"maxrank": 5,
+			"effect": [null, [25, 25, 25, 25, 25], [20, 35, 50, 65, 80]],
+			"vars": [{"link": "attackdamage", "coeff": 1, "key": "f1"}]
+		}`)
+
+		damage := ParseSpellDamage(spell)
+
+		assert.Equal(t, []float64{20, 35, 50, 65, 80}, damage.Values)
+		assert.Equal(t, lol.DamagePhysical, damage.Type)
+		assert.Equal(t, 2, damage.Effect)
+		assert.Equal(t, map[string][]float64{"TotalADRatio": {1, 1, 1, 1, 1}}, damage.Ratios)
+		assert.Equal(t, confidenceLeveltipAndTooltip, damage.Confidence)
+	})
+
+	t.Run("tooltip only, from effect burn", func(t *testing.T) {
+		spell := newTestSpell(t, `{
+			"id": "GarenE",
+			"tooltip": "Garen spins for 3 seconds, dealing {{ e1 }} true damage each second.",
+			"maxrank": 3,
+			"effect": [null, null],
+			"effectBurn": [null, "14/18/22"]
+		}`)
+
+		damage := ParseSpellDamage(spell)
+
+		assert.Equal(t, []float64{14, 18, 22}, damage.Values)
+		assert.Equal(t, lol.DamageTrue, damage.Type)
+		assert.Equal(t, confidenceTypedTooltip, damage.Confidence)
+	})
+
+	t.Run("first effect fallback", func(t *testing.T) {
+		spell := newTestSpell(t, `{
+			"id": "JhinQ",
+			"tooltip": "Jhin throws a cartridge dealing {{ totaldamage }}.",
+			"maxrank": 5,
+			"effect": [null, [45, 70, 95, 120, 145]]
+		}`)
+
+		damage := ParseSpellDamage(spell)
+
+		assert.Equal(t, []float64{45, 70, 95, 120, 145}, damage.Values)
+		assert.Equal(t, confidenceFirstEffect, damage.Confidence)
+	})
+
+	t.Run("negative first effect", func(t *testing.T) {
+		spell := newTestSpell(t, `{
+			"id": "DianaR",
+			"tooltip": "Diana reveals and draws in all nearby enemy champions and slows them by {{ slowamount }}.",
+			"maxrank": 3,
+			"effect": [null, [-0.35, -0.4, -0.45]]
+		}`)
+
+		damage := ParseSpellDamage(spell)
+
+		assert.Equal(t, SpellDamage{}, damage)
+	})
+
+	t.Run("no damage", func(t *testing.T) {
+		spell := newTestSpell(t, `{
+			"id": "NunuW",
+			"tooltip": "Enemies hit are slowed by {{ e1 }}%.",
+			"maxrank": 5,
+			"effect": [null, [30, 35, 40, 45, 50], [0, 0, 0, 0, 0]]
+		}`)
+
+		damage := ParseSpellDamage(spell)
+
+		assert.Equal(t, SpellDamage{}, damage)
+	})
+}
+
+func TestParseEffectBurn(t *testing.T) {
+	assert.Equal(t, []float64{60, 95, 130}, parseEffectBurn("60/95/130", 3))
+	assert.Equal(t, []float64{2, 2, 2}, parseEffectBurn("2", 3))
+	assert.Nil(t, parseEffectBurn("n/a", 3))
+}